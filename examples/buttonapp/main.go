@@ -19,7 +19,6 @@ import (
 	"github.com/eclipse/paho.golang/paho"
 
 	mqtthass "github.com/joshuar/go-hass-anything/v12/pkg/hass"
-	mqttapi "github.com/joshuar/go-hass-anything/v12/pkg/mqtt"
 )
 
 const (
@@ -28,13 +27,13 @@ const (
 )
 
 type ButtonApp struct {
-	entity *mqtthass.ButtonEntity
+	*mqtthass.EntitySet
 }
 
 func New(_ context.Context) (*ButtonApp, error) {
 	app := &ButtonApp{}
 
-	app.entity = mqtthass.NewButtonEntity().
+	button := mqtthass.NewButtonEntity().
 		WithDetails(
 			mqtthass.App(appName),
 			mqtthass.Name("Open HA Homepage"),
@@ -43,6 +42,10 @@ func New(_ context.Context) (*ButtonApp, error) {
 		).
 		WithCommand(mqtthass.CommandCallback(app.buttonCommandCallback))
 
+	// The entity set provides the Configuration, States and Subscriptions
+	// methods of the app.
+	app.EntitySet = mqtthass.NewEntitySet(button)
+
 	return app, nil
 }
 
@@ -50,29 +53,6 @@ func (a *ButtonApp) Name() string {
 	return appName
 }
 
-func (a *ButtonApp) Configuration() []*mqttapi.Msg {
-	buttonEntityCfg, err := a.entity.MarshalConfig()
-	if err != nil {
-		slog.Error("Could not marshal sensor entity config.", "error", err)
-
-		return nil
-	}
-
-	return []*mqttapi.Msg{buttonEntityCfg}
-}
-
-// States is unused. A button has no state.
-func (a *ButtonApp) States() []*mqttapi.Msg { return nil }
-
-func (a *ButtonApp) Subscriptions() []*mqttapi.Subscription {
-	buttonEntitySub, err := a.entity.MarshalSubscription()
-	if err != nil {
-		slog.Warn("Unable to marshal text state subscription.", "error", err.Error())
-	}
-
-	return []*mqttapi.Subscription{buttonEntitySub}
-}
-
 // Update is unused, there is no app data to update.
 func (a *ButtonApp) Update(_ context.Context) error { return nil }
 
@@ -46,6 +46,7 @@ var ErrUnsupportedOS = errors.New("example camera app only runs on Linux")
 
 // CameraApp is our struct that represents an app to Go Hass Anything.
 type CameraApp struct {
+	*mqtthass.EntitySet
 	camera      *webcam.Webcam
 	images      *mqtthass.CameraEntity
 	startButton *mqtthass.ButtonEntity
@@ -120,6 +121,10 @@ func New(ctx context.Context) (*CameraApp, error) {
 			}),
 		)
 
+	// The camera has no state or attributes, so only the buttons will have
+	// subscriptions.
+	app.EntitySet = mqtthass.NewEntitySet(app.images, app.startButton, app.stopButton)
+
 	go func() {
 		defer close(app.msgCh)
 
@@ -134,64 +139,6 @@ func (a *CameraApp) Name() string {
 	return appName
 }
 
-//nolint:mnd
-func (a *CameraApp) Configuration() []*mqttapi.Msg {
-	configs := make([]*mqttapi.Msg, 0, 3)
-
-	cameraCfg, err := a.images.MarshalConfig()
-	if err != nil {
-		slog.Error("Could not marshal camera entity config.",
-			slog.Any("error", err))
-	} else {
-		configs = append(configs, cameraCfg)
-	}
-
-	startButtonCfg, err := a.startButton.MarshalConfig()
-	if err != nil {
-		slog.Error("Could not marshal start button entity config.",
-			slog.Any("error", err))
-	} else {
-		configs = append(configs, startButtonCfg)
-	}
-
-	stopButtonCfg, err := a.stopButton.MarshalConfig()
-	if err != nil {
-		slog.Error("Could not marshal start button entity config.",
-			slog.Any("error", err))
-	} else {
-		configs = append(configs, stopButtonCfg)
-	}
-
-	return configs
-}
-
-// States is unused in this example app. This could be used to take an image
-// snapshot via the webcam or expose other camera information as entities...
-func (a *CameraApp) States() []*mqttapi.Msg { return nil }
-
-//nolint:mnd
-func (a *CameraApp) Subscriptions() []*mqttapi.Subscription {
-	subs := make([]*mqttapi.Subscription, 0, 2)
-
-	startButtonSub, err := a.startButton.MarshalSubscription()
-	if err != nil {
-		slog.Warn("Unable to marshal start button subscription.",
-			slog.Any("error", err))
-	} else {
-		subs = append(subs, startButtonSub)
-	}
-
-	stopButtonSub, err := a.stopButton.MarshalSubscription()
-	if err != nil {
-		slog.Warn("Unable to marshal stop button subscription.",
-			slog.Any("error", err))
-	} else {
-		subs = append(subs, stopButtonSub)
-	}
-
-	return subs
-}
-
 // Update is unused, there is no app data to update.
 func (a *CameraApp) Update(_ context.Context) error { return nil }
 
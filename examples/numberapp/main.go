@@ -15,10 +15,7 @@ import (
 	"log/slog"
 	"strconv"
 
-	"github.com/eclipse/paho.golang/paho"
-
 	mqtthass "github.com/joshuar/go-hass-anything/v12/pkg/hass"
-	mqttapi "github.com/joshuar/go-hass-anything/v12/pkg/mqtt"
 )
 
 const (
@@ -30,7 +27,7 @@ const (
 )
 
 type NumberApp struct {
-	entity      *mqtthass.NumberEntity[int]
+	*mqtthass.EntitySet
 	entityState int
 }
 
@@ -42,7 +39,7 @@ func New(_ context.Context) (*NumberApp, error) {
 	// Our number entity. The value can be an int or float type. The min/max can
 	// be any value in the range of the type in use. We configure the entity to
 	// appear as a slider in Home Assistant.
-	app.entity = mqtthass.NewNumberEntity[int]().
+	number := mqtthass.NewNumberEntity[int]().
 		WithMin(minNum).WithMax(maxNum).WithStep(1).WithMode(mqtthass.NumberSlider).
 		WithDetails(
 			mqtthass.App(appName),
@@ -54,9 +51,9 @@ func New(_ context.Context) (*NumberApp, error) {
 			mqtthass.StateCallback(app.numberStateCallback),
 			mqtthass.ValueTemplate("{{ value_json.value }}"),
 		).
-		WithCommand(
-			mqtthass.CommandCallback(app.numberCommandCallback),
-		)
+		WithCommandHandler(app.numberCommandHandler)
+
+	app.EntitySet = mqtthass.NewEntitySet(number)
 
 	return app, nil
 }
@@ -65,35 +62,6 @@ func (a *NumberApp) Name() string {
 	return appName
 }
 
-func (a *NumberApp) Configuration() []*mqttapi.Msg {
-	numberEntityCfg, err := a.entity.MarshalConfig()
-	if err != nil {
-		slog.Error("Could not marshal number entity config.", "error", err)
-
-		return nil
-	}
-
-	return []*mqttapi.Msg{numberEntityCfg}
-}
-
-func (a *NumberApp) States() []*mqttapi.Msg {
-	numberEntityState, err := a.entity.MarshalState()
-	if err != nil {
-		slog.Warn("Unable to marshal number state to MQTT message.", "error", err.Error())
-	}
-
-	return []*mqttapi.Msg{numberEntityState}
-}
-
-func (a *NumberApp) Subscriptions() []*mqttapi.Subscription {
-	numberEntitySub, err := a.entity.MarshalSubscription()
-	if err != nil {
-		slog.Warn("Unable to marshal number state subscription.", "error", err.Error())
-	}
-
-	return []*mqttapi.Subscription{numberEntitySub}
-}
-
 // Update is unused, there is no app data to update.
 func (a *NumberApp) Update(_ context.Context) error { return nil }
 
@@ -103,16 +71,13 @@ func (a *NumberApp) numberStateCallback(_ ...any) (json.RawMessage, error) {
 	return json.RawMessage(`{ "value": ` + strconv.Itoa(a.entityState) + ` }`), nil
 }
 
-// numberCommandCallback is our callback function for when a request to change the value is
-// received on MQTT, we set our state internally and publish back on the
-// state topic for any listeners.
-func (a *NumberApp) numberCommandCallback(p *paho.Publish) {
-	if newValue, err := strconv.Atoi(string(p.Payload)); err != nil {
-		slog.Warn("Could not parse new value for number.", "error", err.Error())
-	} else {
-		slog.Info("Number was changed.", slog.Int("value", newValue))
-		a.entityState = newValue
-	}
+// numberCommandHandler is our handler for when a request to change the value
+// is received on MQTT. The value has already been converted to an int and
+// checked against the min/max of the entity, so we just set our state
+// internally.
+func (a *NumberApp) numberCommandHandler(value int) {
+	slog.Info("Number was changed.", slog.Int("value", value))
+	a.entityState = value
 }
 
 func newDevice() *mqtthass.Device {
@@ -21,10 +21,10 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"strconv"
 	"time"
 
 	mqtthass "github.com/joshuar/go-hass-anything/v12/pkg/hass"
-	mqttapi "github.com/joshuar/go-hass-anything/v12/pkg/mqtt"
 	"github.com/joshuar/go-hass-anything/v12/pkg/preferences"
 	"github.com/joshuar/go-hass-anything/v12/pkg/web"
 )
@@ -37,18 +37,33 @@ const (
 	weatherURLpref = "weatherURL"
 	pollInterval   = time.Minute
 	pollJitter     = 5 * time.Second
+
+	// maxStateAge is how long the temperature can remain unchanged before it
+	// is published again anyway.
+	maxStateAge = 15 * time.Minute
 )
 
 var ErrFetchWeatherFailed = errors.New("could not get weather data")
 
 type SensorApp struct {
-	entity      *mqtthass.SensorEntity
+	*mqtthass.EntitySet
 	prefs       *Prefs
-	weatherData map[string]any
+	temperature *mqtthass.TypedState[float64]
+}
+
+// weatherData is the part of the weather service response that we are
+// interested in.
+type weatherData struct {
+	CurrentCondition []struct {
+		TempC string `json:"temp_C"`
+	} `json:"current_condition"`
 }
 
 func New(_ context.Context) (*SensorApp, error) {
-	app := &SensorApp{}
+	app := &SensorApp{
+		// The temperature will be unknown until we have fetched the weather.
+		temperature: mqtthass.NewTypedState[float64](),
+	}
 
 	prefs, err := preferences.LoadApp(app)
 	if err != nil {
@@ -57,7 +72,7 @@ func New(_ context.Context) (*SensorApp, error) {
 
 	app.prefs = prefs
 
-	app.entity = mqtthass.NewSensorEntity().
+	sensor := mqtthass.NewSensorEntity().
 		WithDetails(
 			mqtthass.App(appName),
 			mqtthass.Name("Weather Temp"),
@@ -65,13 +80,18 @@ func New(_ context.Context) (*SensorApp, error) {
 			mqtthass.DeviceInfo(newDevice()),
 		).
 		WithState(
-			mqtthass.ValueTemplate("{{ value_json.current_condition[0].temp_C }}"),
-			mqtthass.StateCallback(app.weatherStateCallback),
+			mqtthass.TypedStateCallback(app.temperature),
 			mqtthass.StateClassMeasurement(),
 			mqtthass.Units("°C"),
-			mqtthass.DeviceClass("temperature"),
+			mqtthass.SensorDeviceClass(mqtthass.SensorTypeTemperature),
+			mqtthass.OnlyOnChange(maxStateAge),
+		).
+		WithAvailability(
+			mqtthass.AppAvailability(appName),
 		)
 
+	app.EntitySet = mqtthass.NewEntitySet(sensor)
+
 	return app, nil
 }
 
@@ -79,29 +99,6 @@ func (a *SensorApp) Name() string {
 	return appName
 }
 
-func (a *SensorApp) Configuration() []*mqttapi.Msg {
-	sensorEntityCfg, err := a.entity.MarshalConfig()
-	if err != nil {
-		slog.Error("Could not marshal sensor entity config.", slog.Any("error", err))
-
-		return nil
-	}
-
-	return []*mqttapi.Msg{sensorEntityCfg}
-}
-
-func (a *SensorApp) States() []*mqttapi.Msg {
-	sensorEntityState, err := a.entity.MarshalState()
-	if err != nil {
-		slog.Warn("Unable to marshal sensor state to MQTT message.", slog.Any("error", err.Error()))
-	}
-
-	return []*mqttapi.Msg{sensorEntityState}
-}
-
-// Subscriptions is unused. Sensors do not have any interactivity requirements.
-func (a *SensorApp) Subscriptions() []*mqttapi.Subscription { return nil }
-
 // Update will fetch the remote data.
 func (a *SensorApp) Update(ctx context.Context) error {
 	// We fetch the weather using the web.ExecuteRequest helper. As our app
@@ -173,32 +170,38 @@ func (a *SensorApp) URL() string {
 
 // We also need a way to save the response of the web request, and we can do
 // this by satisfying the web.Response interface through adding a UnmarshalJSON
-// that will take the raw response JSON and save it into our app struct.
+// that will take the raw response JSON and save the temperature as the value of
+// our sensor state. The agent will then publish it on the polling interval.
 func (a *SensorApp) UnmarshalJSON(data []byte) error {
-	if err := json.Unmarshal(data, &a.weatherData); err != nil {
+	var weather weatherData
+
+	if err := json.Unmarshal(data, &weather); err != nil {
 		return fmt.Errorf("could not parse web response: %w", err)
 	}
 
-	return nil
-}
+	if len(weather.CurrentCondition) == 0 {
+		a.temperature.Reset()
 
-// weatherStateCallback is called on the polling interval when we need to publish
-// the weather.
-func (a *SensorApp) weatherStateCallback(_ ...any) (json.RawMessage, error) {
-	payload, err := json.Marshal(a.weatherData)
+		return ErrFetchWeatherFailed
+	}
+
+	temperature, err := strconv.ParseFloat(weather.CurrentCondition[0].TempC, 64)
 	if err != nil {
-		return nil, fmt.Errorf("could not marshal weather data to state payload: %w", err)
+		a.temperature.Reset()
+
+		return fmt.Errorf("could not parse temperature: %w", err)
 	}
 
-	return payload, nil
+	a.temperature.Set(temperature)
+
+	return nil
 }
 
 func newDevice() *mqtthass.Device {
-	return &mqtthass.Device{
-		Name:         appName,
-		Identifiers:  []string{appID},
-		URL:          "https://github.com/joshuar/go-hass-anything",
-		Manufacturer: "go-hass-anything",
-		Model:        appID,
-	}
+	return mqtthass.NewDevice(appName,
+		mqtthass.WithIdentifiers(appID),
+		mqtthass.WithConfigurationURL("https://github.com/joshuar/go-hass-anything"),
+		mqtthass.WithManufacturer("go-hass-anything"),
+		mqtthass.WithModel(appID, ""),
+	)
 }
@@ -14,8 +14,6 @@ import (
 	"encoding/json"
 	"log/slog"
 
-	"github.com/eclipse/paho.golang/paho"
-
 	mqtthass "github.com/joshuar/go-hass-anything/v12/pkg/hass"
 	mqttapi "github.com/joshuar/go-hass-anything/v12/pkg/mqtt"
 )
@@ -26,6 +24,7 @@ const (
 )
 
 type SwitchApp struct {
+	*mqtthass.EntitySet
 	entity      *mqtthass.SwitchEntity
 	msgCh       chan *mqttapi.Msg
 	entityState bool
@@ -48,9 +47,9 @@ func New(_ context.Context) (*SwitchApp, error) {
 			mqtthass.StateCallback(app.switchStateCallback),
 			mqtthass.ValueTemplate("{{ value }}"),
 		).
-		WithCommand(
-			mqtthass.CommandCallback(app.switchCommandCallback),
-		)
+		WithCommandHandler(app.switchCommandHandler)
+
+	app.EntitySet = mqtthass.NewEntitySet(app.entity)
 
 	return app, nil
 }
@@ -59,35 +58,6 @@ func (a *SwitchApp) Name() string {
 	return appName
 }
 
-func (a *SwitchApp) Configuration() []*mqttapi.Msg {
-	switchEntityCfg, err := a.entity.MarshalConfig()
-	if err != nil {
-		slog.Error("Could not marshal switch entity config.", "error", err)
-
-		return nil
-	}
-
-	return []*mqttapi.Msg{switchEntityCfg}
-}
-
-func (a *SwitchApp) States() []*mqttapi.Msg {
-	switchEntityState, err := a.entity.MarshalState()
-	if err != nil {
-		slog.Warn("Unable to marshal switch state to MQTT message.", "error", err.Error())
-	}
-
-	return []*mqttapi.Msg{switchEntityState}
-}
-
-func (a *SwitchApp) Subscriptions() []*mqttapi.Subscription {
-	switchEntitySub, err := a.entity.MarshalSubscription()
-	if err != nil {
-		slog.Warn("Unable to marshal switch state subscription.", "error", err.Error())
-	}
-
-	return []*mqttapi.Subscription{switchEntitySub}
-}
-
 // Update is unused, there is no app data to update.
 func (a *SwitchApp) Update(_ context.Context) error { return nil }
 
@@ -108,21 +78,13 @@ func (a *SwitchApp) switchStateCallback(_ ...any) (json.RawMessage, error) {
 	}
 }
 
-// switchCommandCallback is our callback function for when the switch entity is
+// switchCommandHandler is our handler for when the switch entity is
 // manipulated in Home Assistant.
-func (a *SwitchApp) switchCommandCallback(p *paho.Publish) {
+func (a *SwitchApp) switchCommandHandler(on bool) {
 	// Record the new state.
-	state := string(p.Payload)
-	switch state {
-	case "ON":
-		slog.Info("Switch was turned on.")
+	slog.Info("Switch was toggled.", slog.Bool("on", on))
 
-		a.entityState = true
-	case "OFF":
-		slog.Info("Switch was turned off.")
-
-		a.entityState = false
-	}
+	a.entityState = on
 	// Publish a message with the new state.
 	msg, err := a.entity.MarshalState()
 	if err != nil {
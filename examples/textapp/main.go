@@ -16,10 +16,7 @@ import (
 	"log/slog"
 	"os/exec"
 
-	"github.com/eclipse/paho.golang/paho"
-
 	mqtthass "github.com/joshuar/go-hass-anything/v12/pkg/hass"
-	mqttapi "github.com/joshuar/go-hass-anything/v12/pkg/mqtt"
 )
 
 const (
@@ -31,14 +28,14 @@ const (
 )
 
 type TextApp struct {
-	entity *mqtthass.TextEntity
-	text   string
+	*mqtthass.EntitySet
+	text string
 }
 
 func New(_ context.Context) (*TextApp, error) {
 	app := &TextApp{}
 	app.text = "Replace this text and hit return!"
-	app.entity = mqtthass.NewTextEntity().
+	text := mqtthass.NewTextEntity().
 		WithMode(mqtthass.PlainText).
 		WithMin(minTextLen).
 		WithMax(maxTextLen).
@@ -48,14 +45,14 @@ func New(_ context.Context) (*TextApp, error) {
 			mqtthass.ID("text"),
 			mqtthass.DeviceInfo(newDevice()),
 		).
-		WithCommand(
-			mqtthass.CommandCallback(app.commandCallback),
-		).
+		WithCommandHandler(app.commandHandler).
 		WithState(
 			mqtthass.StateCallback(app.stateCallback),
 			mqtthass.ValueTemplate("{{ value }}"),
 		)
 
+	app.EntitySet = mqtthass.NewEntitySet(text)
+
 	return app, nil
 }
 
@@ -63,38 +60,6 @@ func (a *TextApp) Name() string {
 	return appName
 }
 
-func (a *TextApp) Configuration() []*mqttapi.Msg {
-	textEntityCfg, err := a.entity.MarshalConfig()
-	if err != nil {
-		slog.Error("Could not marshal text entity config.",
-			slog.Any("error", err))
-
-		return nil
-	}
-
-	return []*mqttapi.Msg{textEntityCfg}
-}
-
-func (a *TextApp) States() []*mqttapi.Msg {
-	textEntityState, err := a.entity.MarshalState()
-	if err != nil {
-		slog.Warn("Unable to marshal text state to MQTT message.",
-			slog.Any("error", err))
-	}
-
-	return []*mqttapi.Msg{textEntityState}
-}
-
-func (a *TextApp) Subscriptions() []*mqttapi.Subscription {
-	textEntitySub, err := a.entity.MarshalSubscription()
-	if err != nil {
-		slog.Warn("Unable to marshal text state subscription.",
-			slog.Any("error", err))
-	}
-
-	return []*mqttapi.Subscription{textEntitySub}
-}
-
 // Update is unused, there is no app data to update.
 func (a *TextApp) Update(_ context.Context) error { return nil }
 
@@ -112,8 +77,8 @@ func (a *TextApp) stateCallback(_ ...any) (json.RawMessage, error) {
 	return json.RawMessage(a.text), nil
 }
 
-func (a *TextApp) commandCallback(p *paho.Publish) {
-	a.text = string(p.Payload)
+func (a *TextApp) commandHandler(text string) {
+	a.text = text
 	if err := exec.Command("notify-send", a.text).Run(); err != nil {
 		slog.Warn("Could not execute notify-send.",
 			slog.Any("error", err))
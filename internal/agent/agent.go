@@ -13,10 +13,15 @@ import (
 	"time"
 
 	"github.com/joshuar/go-hass-anything/v12/internal/logging"
+	"github.com/joshuar/go-hass-anything/v12/pkg/hass"
 	"github.com/joshuar/go-hass-anything/v12/pkg/mqtt"
 	"github.com/joshuar/go-hass-anything/v12/pkg/preferences"
 )
 
+// shutdownTimeout is how long the agent waits to mark the apps unavailable
+// when it is stopped, so that it does not hang if the broker is unreachable.
+const shutdownTimeout = 5 * time.Second
+
 //go:generate go run ../../tools/appgenerator/main.go
 var (
 	// AppList is the list of apps to run under the agent. It is generated at
@@ -41,13 +46,13 @@ type App interface {
 	Name() string
 	// Configuration() returns the messages needed to tell Home Assistant how to
 	// configure the app and its entities.
-	Configuration() []*mqtt.Msg
+	Configuration() ([]*mqtt.Msg, error)
 	// States() are the messages that reflect the app's current state of the
 	// entities of the app.
-	States() []*mqtt.Msg
+	States() ([]*mqtt.Msg, error)
 	// Subscriptions() are the topics on which the app wants to subscribe and
 	// execute a callback in response to a message on that topic.
-	Subscriptions() []*mqtt.Subscription
+	Subscriptions() ([]*mqtt.Subscription, error)
 	// Update() is a function that is run at least once by the agent and will
 	// usually contain the logic to update the states of all the apps entities.
 	// It may be run multiple times, if the app is also considered a polling
@@ -187,34 +192,80 @@ func Run(ctx context.Context) error {
 	var (
 		subscriptions []*mqtt.Subscription
 		configs       []*mqtt.Msg
+		incomplete    []string
+		published     = make(manifest)
 	)
 	// Get the agent preferences.
 	if err := preferences.Load(); err != nil {
 		return fmt.Errorf("run: %w", err)
 	}
+	// Generate entity topics under the configured topic prefix.
+	hass.HomeAssistantTopic = preferences.Agent.TopicPrefix()
 	// Initialize apps.
 	initApps()
 	// Generate configs and subscriptions for apps.
 	for _, app := range AppList {
-		configs = append(configs, app.Configuration()...)
-		subscriptions = append(subscriptions, app.Subscriptions()...)
+		appConfigs, err := appConfiguration(ctx, app)
+		if err != nil {
+			incomplete = append(incomplete, app.Name())
+		}
+
+		configs = append(configs, appConfigs...)
+		published.add(app.Name(), appConfigs)
+		subscriptions = append(subscriptions, appSubscriptions(ctx, app)...)
 	}
-	// Start the MQTT client with the given subscriptions and configs.
-	client, err := mqtt.NewClient(ctx, preferences.Agent, subscriptions, configs)
+	// Start the MQTT client with the given subscriptions and configs. The
+	// client outlives the agent context, so that the apps can be marked
+	// unavailable once the agent is stopped.
+	clientCtx, cancelClient := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancelClient()
+
+	// The agent availability is registered as the Last Will of the client, so
+	// that the entities of all apps are marked unavailable if the agent stops
+	// unexpectedly.
+	client, err := mqtt.NewClient(clientCtx, preferences.Agent, subscriptions, configs,
+		mqtt.WithAvailability(hass.AgentAvailabilityTopic(), []byte(hass.PayloadAvailable), []byte(hass.PayloadNotAvailable)))
 	if err != nil {
 		return fmt.Errorf("run: %w", err)
 	}
+	// Home Assistant will not have any states published while it was offline,
+	// so publish all states once it is back, even if they have not changed.
+	client.OnHomeAssistantOnline(hass.ResetChanges)
+	// Remove the configs of any entities the apps no longer have.
+	if err := pruneApps(ctx, client, published, incomplete, false); err != nil {
+		logging.FromContext(ctx).Warn("Could not remove stale entity configuration.",
+			slog.Any("error", err))
+	}
 	// Run the apps.
 	runApps(ctx, client, AppList)
+	// Wait for the agent to be stopped, then mark all apps unavailable.
+	<-ctx.Done()
+
+	shutdownCtx, cancelShutdown := context.WithTimeout(context.WithoutCancel(ctx), shutdownTimeout)
+	defer cancelShutdown()
+
+	for _, app := range AppList {
+		publishAppAvailability(shutdownCtx, app, client, false)
+	}
+
+	if err := client.Publish(shutdownCtx, hass.MarshalAgentAvailability(false)); err != nil {
+		logging.FromContext(ctx).Warn("Failed to publish agent availability.",
+			slog.Any("error", err))
+	}
 
 	return nil
 }
 
-// ClearApps removes any stored messages for any apps from MQTT.
+// ClearApps removes any stored messages for any apps from MQTT, including the
+// configs of any entities recorded in the manifest of published configs.
 func ClearApps(ctx context.Context) error {
 	if err := preferences.Load(); err != nil {
 		return fmt.Errorf("clear: %w", err)
 	}
+	// Generate entity topics under the configured topic prefix.
+	hass.HomeAssistantTopic = preferences.Agent.TopicPrefix()
+	// Initialize apps.
+	initApps()
 
 	client, err := mqtt.NewClient(ctx, preferences.Agent, nil, nil)
 	if err != nil {
@@ -225,7 +276,8 @@ func ClearApps(ctx context.Context) error {
 		logging.FromContext(ctx).Debug("Removing configuration from MQTT for app.",
 			slog.String("app", app.Name()))
 
-		if err := client.Unpublish(ctx, app.Configuration()...); err != nil {
+		configs, _ := appConfiguration(ctx, app)
+		if err := client.Unpublish(ctx, configs...); err != nil {
 			logging.FromContext(ctx).Warn("Could not remove configuration from MQTT for app.",
 				slog.String("app", app.Name()),
 				slog.Any("error", err))
@@ -234,6 +286,65 @@ func ClearApps(ctx context.Context) error {
 		}
 	}
 
+	published, err := loadManifest()
+	if err != nil {
+		return fmt.Errorf("clear: %w", err)
+	}
+
+	if err := client.Unpublish(ctx, published.msgs()...); err != nil {
+		return fmt.Errorf("clear: %w", err)
+	}
+
+	if err := removeManifest(); err != nil {
+		return fmt.Errorf("clear: %w", err)
+	}
+
+	return nil
+}
+
+// Prune removes the configs of any entities previously published by the agent
+// that no longer belong to any app, such as entities that have been renamed or
+// removed, or that belong to apps that have been removed. If dryRun is true,
+// the stale configs are only reported.
+func Prune(ctx context.Context, dryRun bool) error {
+	if err := preferences.Load(); err != nil {
+		return fmt.Errorf("prune: %w", err)
+	}
+	// Generate entity topics under the configured topic prefix.
+	hass.HomeAssistantTopic = preferences.Agent.TopicPrefix()
+	// Initialize apps.
+	initApps()
+
+	var incomplete []string
+
+	current := make(manifest)
+
+	for _, app := range AppList {
+		configs, err := appConfiguration(ctx, app)
+		if err != nil {
+			incomplete = append(incomplete, app.Name())
+		}
+
+		current.add(app.Name(), configs)
+	}
+
+	if dryRun {
+		if err := pruneApps(ctx, nil, current, incomplete, true); err != nil {
+			return fmt.Errorf("prune: %w", err)
+		}
+
+		return nil
+	}
+
+	client, err := mqtt.NewClient(ctx, preferences.Agent, nil, nil)
+	if err != nil {
+		return fmt.Errorf("prune: %w", err)
+	}
+
+	if err := pruneApps(ctx, client, current, incomplete, true); err != nil {
+		return fmt.Errorf("prune: %w", err)
+	}
+
 	return nil
 }
 
@@ -262,7 +373,7 @@ func runApps(ctx context.Context, client *mqtt.Client, apps []App) {
 				runEventsApp(ctx, client, logger, app)
 			}()
 		default:
-			updateApp(ctx, app)
+			publishAppAvailability(ctx, app, client, updateApp(ctx, app) == nil)
 			publishAppStates(ctx, app, client)
 		}
 	}
@@ -270,7 +381,9 @@ func runApps(ctx context.Context, client *mqtt.Client, apps []App) {
 	wg.Wait()
 }
 
-func updateApp(ctx context.Context, app App) {
+// updateApp runs the Update function of the app. Any error is logged and
+// returned, so that the app can be marked unavailable.
+func updateApp(ctx context.Context, app App) error {
 	logger := logging.FromContext(ctx)
 
 	logger.Debug("Updating app.",
@@ -280,6 +393,27 @@ func updateApp(ctx context.Context, app App) {
 		logger.Warn("Failed to update app.",
 			slog.String("app", app.Name()),
 			slog.Any("error", err))
+
+		return err
+	}
+
+	return nil
+}
+
+// publishAppAvailability publishes the availability of the app. Entities of
+// the app that use the app availability topic will be marked available or
+// unavailable in Home Assistant accordingly.
+func publishAppAvailability(ctx context.Context, app App, client *mqtt.Client, available bool) {
+	logger := logging.FromContext(ctx)
+
+	logger.Debug("Publishing app availability.",
+		slog.String("app", app.Name()),
+		slog.Bool("available", available))
+
+	if err := client.Publish(ctx, hass.MarshalAppAvailability(app.Name(), available)); err != nil {
+		logger.Warn("Failed to publish app availability.",
+			slog.String("app", app.Name()),
+			slog.Any("error", err))
 	}
 }
 
@@ -289,23 +423,65 @@ func publishAppStates(ctx context.Context, app App, client *mqtt.Client) {
 	logger.Debug("Publishing app states.",
 		slog.String("app", app.Name()))
 
-	if err := client.Publish(ctx, app.States()...); err != nil {
+	states, err := app.States()
+	if err != nil {
+		logger.Warn("Could not generate some app states.",
+			slog.String("app", app.Name()),
+			slog.Any("error", err))
+	}
+
+	if err := client.Publish(ctx, states...); err != nil {
 		logger.Warn("Failed to publish app states.",
 			slog.String("app", app.Name()),
 			slog.Any("error", err))
 	}
 }
 
+// appConfiguration returns the config messages of the app. Any errors
+// generating the configs are logged and only the valid configs are returned,
+// along with the error.
+func appConfiguration(ctx context.Context, app App) ([]*mqtt.Msg, error) {
+	configs, err := app.Configuration()
+	if err != nil {
+		logging.FromContext(ctx).Warn("Could not generate some app configuration.",
+			slog.String("app", app.Name()),
+			slog.Any("error", err))
+	}
+
+	return configs, err
+}
+
+// appSubscriptions returns the subscriptions of the app. Any errors generating
+// the subscriptions are logged and only the valid subscriptions are returned.
+func appSubscriptions(ctx context.Context, app App) []*mqtt.Subscription {
+	subscriptions, err := app.Subscriptions()
+	if err != nil {
+		logging.FromContext(ctx).Warn("Could not generate some app subscriptions.",
+			slog.String("app", app.Name()),
+			slog.Any("error", err))
+	}
+
+	return subscriptions
+}
+
 func runPollingApp(ctx context.Context, client *mqtt.Client, logger *slog.Logger, app PollingApp) {
 	interval, jitter := app.PollConfig()
 
 	logger.Info("Running loop to poll app for updates.",
 		slog.String("app", app.Name()))
 
+	var available *bool
+
 	err := poll(
 		ctx,
 		func() {
-			updateApp(ctx, app)
+			updated := updateApp(ctx, app) == nil
+			// Only publish the availability of the app when it changes.
+			if available == nil || *available != updated {
+				available = &updated
+				publishAppAvailability(ctx, app, client, updated)
+			}
+
 			publishAppStates(ctx, app, client)
 		},
 		interval,
@@ -315,11 +491,12 @@ func runPollingApp(ctx context.Context, client *mqtt.Client, logger *slog.Logger
 		logger.Error("Failed to poll app for updates.",
 			slog.String("app", app.Name()),
 			slog.Any("error", err))
+		publishAppAvailability(ctx, app, client, false)
 	}
 }
 
 func runEventsApp(ctx context.Context, client *mqtt.Client, logger *slog.Logger, app EventsApp) {
-	updateApp(ctx, app)
+	publishAppAvailability(ctx, app, client, updateApp(ctx, app) == nil)
 
 	logger.Info("Listening for message events from app.",
 		slog.String("app", app.Name()))
@@ -0,0 +1,198 @@
+// Copyright (c) 2024 Joshua Rich <joshua.rich@gmail.com>
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/adrg/xdg"
+
+	"github.com/joshuar/go-hass-anything/v12/internal/logging"
+	"github.com/joshuar/go-hass-anything/v12/pkg/mqtt"
+)
+
+const (
+	manifestDirPerms  = 0o700
+	manifestFilePerms = 0o600
+)
+
+// manifestFile is the file in which the agent records the config topics it
+// has published.
+var manifestFile = filepath.Join(xdg.ConfigHome, "go-hass-anything", "manifest.json")
+
+// manifest records the config topics published for each app, keyed by app
+// name. By comparing the manifest from a previous run against the current
+// configs of the apps, the agent can find the configs of any entities that
+// have since been removed or renamed, which would otherwise remain in Home
+// Assistant.
+type manifest map[string][]string
+
+// add records the topics of the given configs for the app.
+func (m manifest) add(app string, configs []*mqtt.Msg) {
+	topics := make([]string, 0, len(configs))
+
+	for _, config := range configs {
+		topics = append(topics, config.Topic)
+	}
+
+	m.addTopics(app, topics...)
+}
+
+// addTopics records the given topics for the app, in addition to any already
+// recorded.
+func (m manifest) addTopics(app string, topics ...string) {
+	topics = append(slices.Clone(m[app]), topics...)
+	slices.Sort(topics)
+
+	m[app] = slices.Compact(topics)
+}
+
+// stale returns the topics in the manifest that are not in the current
+// manifest. Apps that are not in the current manifest are only included if
+// removedApps is true, otherwise it is assumed they are not running rather
+// than removed.
+func (m manifest) stale(current manifest, removedApps bool) manifest {
+	stale := make(manifest)
+
+	for app, topics := range m {
+		currentTopics, found := current[app]
+		if !found && !removedApps {
+			continue
+		}
+
+		for _, topic := range topics {
+			if !slices.Contains(currentTopics, topic) {
+				stale[app] = append(stale[app], topic)
+			}
+		}
+	}
+
+	return stale
+}
+
+// msgs returns a message for each topic in the manifest.
+func (m manifest) msgs() []*mqtt.Msg {
+	var msgs []*mqtt.Msg
+
+	for _, topics := range m {
+		for _, topic := range topics {
+			msgs = append(msgs, mqtt.NewMsg(topic, nil))
+		}
+	}
+
+	return msgs
+}
+
+// save writes the manifest to disk.
+func (m manifest) save() error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("save manifest: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(manifestFile), manifestDirPerms); err != nil {
+		return fmt.Errorf("save manifest: %w", err)
+	}
+
+	if err := os.WriteFile(manifestFile, data, manifestFilePerms); err != nil {
+		return fmt.Errorf("save manifest: %w", err)
+	}
+
+	return nil
+}
+
+// loadManifest reads the manifest from disk. If there is no manifest, an empty
+// one is returned.
+func loadManifest() (manifest, error) {
+	published := make(manifest)
+
+	data, err := os.ReadFile(manifestFile)
+	if errors.Is(err, fs.ErrNotExist) {
+		return published, nil
+	}
+
+	if err != nil {
+		return published, fmt.Errorf("load manifest: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &published); err != nil {
+		return published, fmt.Errorf("load manifest: %w", err)
+	}
+
+	return published, nil
+}
+
+// removeManifest deletes the manifest from disk.
+func removeManifest() error {
+	if err := os.Remove(manifestFile); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("remove manifest: %w", err)
+	}
+
+	return nil
+}
+
+// pruneApps compares the given manifest of current configs against the
+// manifest from the previous run and removes the stale configs from MQTT. The
+// current manifest is then saved for the next run. If removedApps is true, the
+// configs of apps that are no longer present are also removed, otherwise they
+// are kept in the manifest. Incomplete apps, which could not generate all of
+// their configs, keep all of their previous configs, so that an entity with a
+// temporary config error is not mistaken for one that was removed. If client
+// is nil, the stale configs are only reported.
+func pruneApps(ctx context.Context, client *mqtt.Client, current manifest, incomplete []string, removedApps bool) error {
+	logger := logging.FromContext(ctx)
+
+	previous, err := loadManifest()
+	if err != nil {
+		return err
+	}
+
+	for _, app := range incomplete {
+		current.addTopics(app, previous[app]...)
+	}
+
+	stale := previous.stale(current, removedApps)
+
+	for app, topics := range stale {
+		for _, topic := range topics {
+			if client == nil {
+				logger.Info("Found stale entity configuration.",
+					slog.String("app", app),
+					slog.String("topic", topic))
+			} else {
+				logger.Info("Removing stale entity configuration.",
+					slog.String("app", app),
+					slog.String("topic", topic))
+			}
+		}
+	}
+
+	if client == nil {
+		return nil
+	}
+
+	if err := client.Unpublish(ctx, stale.msgs()...); err != nil {
+		return fmt.Errorf("could not remove stale entity configuration: %w", err)
+	}
+
+	if !removedApps {
+		for app, topics := range previous {
+			if _, found := current[app]; !found {
+				current[app] = topics
+			}
+		}
+	}
+
+	return current.save()
+}
@@ -47,6 +47,26 @@ func (r *ResetCmd) Run(ctx Context) error {
 	return nil
 }
 
+type PruneCmd struct {
+	DryRun bool `help:"Only report stale entities, don't remove them."`
+}
+
+func (r *PruneCmd) Help() string {
+	return `
+Prune will remove the configuration of any entities that were previously
+published but no longer belong to any app, such as entities that were renamed
+or removed, or that belonged to an app that was removed.
+`
+}
+
+func (r *PruneCmd) Run(ctx Context) error {
+	if err := agent.Prune(ctx, r.DryRun); err != nil {
+		return fmt.Errorf("prune agent: %w", err)
+	}
+
+	return nil
+}
+
 type ConfigureCmd struct{}
 
 func (r *ConfigureCmd) Help() string {
@@ -96,6 +116,7 @@ var CLI struct {
 	Run          RunCmd               `cmd:"" help:"Run Go Hass Anything."`
 	Configure    ConfigureCmd         `cmd:"" help:"Configure Go Hass Anything."`
 	Reset        ResetCmd             `cmd:"" help:"Reset Go Hass Anything."`
+	Prune        PruneCmd             `cmd:"" help:"Remove stale entities of Go Hass Anything."`
 	ProfileFlags logging.ProfileFlags `name:"profile" help:"Enable profiling."`
 	LogLevel     string               `name:"log-level" enum:"info,debug,trace" default:"info" help:"Set logging level."`
 	NoLogFile    bool                 `help:"Don't write to a log file."`
@@ -115,8 +136,11 @@ func main() {
 	c := make(chan os.Signal, 1)
 	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
 
+	// Cancel the context on a signal, allowing the agent to shut down
+	// gracefully. Exit immediately on a second signal.
 	go func() {
-		defer cancelFunc()
+		<-c
+		cancelFunc()
 		<-c
 		stopProfiling()
 		os.Exit(-1)
@@ -0,0 +1,409 @@
+// Copyright (c) 2024 Joshua Rich <joshua.rich@gmail.com>
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package hass
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// topicBase is the key used for the base topic in an abbreviated config. Any
+// topic starting or ending with it is expanded with the base topic.
+const topicBase = "~"
+
+// abbreviations maps the full keys of a config to the abbreviations supported
+// by Home Assistant. See
+// https://www.home-assistant.io/integrations/mqtt/#supported-abbreviations-in-mqtt-discovery-messages
+var abbreviations = map[string]string{
+	"action_template":                   "act_tpl",
+	"action_topic":                      "act_t",
+	"automation_type":                   "atype",
+	"availability":                      "avty",
+	"availability_mode":                 "avty_mode",
+	"availability_template":             "avty_tpl",
+	"availability_topic":                "avty_t",
+	"available_tones":                   "av_tones",
+	"brightness_scale":                  "bri_scl",
+	"code_arm_required":                 "cod_arm_req",
+	"code_disarm_required":              "cod_dis_req",
+	"code_trigger_required":             "cod_trig_req",
+	"command_off_template":              "cmd_off_tpl",
+	"command_template":                  "cmd_tpl",
+	"command_topic":                     "cmd_t",
+	"components":                        "cmps",
+	"configuration_url":                 "cu",
+	"connections":                       "cns",
+	"current_humidity_template":         "curr_hum_tpl",
+	"current_humidity_topic":            "curr_hum_t",
+	"current_temperature_template":      "curr_temp_tpl",
+	"current_temperature_topic":         "curr_temp_t",
+	"default_entity_id":                 "def_ent_id",
+	"device":                            "dev",
+	"device_class":                      "dev_cla",
+	"direction_command_template":        "dir_cmd_tpl",
+	"direction_command_topic":           "dir_cmd_t",
+	"direction_state_topic":             "dir_stat_t",
+	"direction_value_template":          "dir_val_tpl",
+	"effect_list":                       "fx_list",
+	"enabled_by_default":                "en",
+	"encoding":                          "e",
+	"entity_category":                   "ent_cat",
+	"entity_picture":                    "ent_pic",
+	"event_types":                       "evt_typ",
+	"expire_after":                      "exp_aft",
+	"fan_mode_command_template":         "fan_mode_cmd_tpl",
+	"fan_mode_command_topic":            "fan_mode_cmd_t",
+	"fan_mode_state_template":           "fan_mode_stat_tpl",
+	"fan_mode_state_topic":              "fan_mode_stat_t",
+	"fan_speed_list":                    "fanspd_lst",
+	"flash_time_long":                   "flsh_tlng",
+	"flash_time_short":                  "flsh_tsht",
+	"force_update":                      "frc_upd",
+	"hw_version":                        "hw",
+	"icon":                              "ic",
+	"identifiers":                       "ids",
+	"image_encoding":                    "img_e",
+	"image_topic":                       "img_t",
+	"initial":                           "init",
+	"json_attributes_template":          "json_attr_tpl",
+	"json_attributes_topic":             "json_attr_t",
+	"last_reset_value_template":         "lrst_val_tpl",
+	"manufacturer":                      "mf",
+	"max_humidity":                      "max_hum",
+	"max_kelvin":                        "max_kvin",
+	"min_humidity":                      "min_hum",
+	"min_kelvin":                        "min_kvin",
+	"mode_command_template":             "mode_cmd_tpl",
+	"mode_command_topic":                "mode_cmd_t",
+	"mode_state_template":               "mode_stat_tpl",
+	"mode_state_topic":                  "mode_stat_t",
+	"model":                             "mdl",
+	"model_id":                          "mdl_id",
+	"object_id":                         "obj_id",
+	"optimistic":                        "opt",
+	"options":                           "ops",
+	"origin":                            "o",
+	"oscillation_command_template":      "osc_cmd_tpl",
+	"oscillation_command_topic":         "osc_cmd_t",
+	"oscillation_state_topic":           "osc_stat_t",
+	"oscillation_value_template":        "osc_val_tpl",
+	"pattern":                           "ptrn",
+	"payload":                           "pl",
+	"payload_arm_away":                  "pl_arm_away",
+	"payload_arm_custom_bypass":         "pl_arm_custom_b",
+	"payload_arm_home":                  "pl_arm_home",
+	"payload_arm_night":                 "pl_arm_nite",
+	"payload_arm_vacation":              "pl_arm_vacation",
+	"payload_available":                 "pl_avail",
+	"payload_clean_spot":                "pl_cln_sp",
+	"payload_close":                     "pl_cls",
+	"payload_disarm":                    "pl_disarm",
+	"payload_home":                      "pl_home",
+	"payload_install":                   "pl_inst",
+	"payload_locate":                    "pl_loc",
+	"payload_lock":                      "pl_lock",
+	"payload_not_available":             "pl_not_avail",
+	"payload_not_home":                  "pl_not_home",
+	"payload_off":                       "pl_off",
+	"payload_on":                        "pl_on",
+	"payload_open":                      "pl_open",
+	"payload_oscillation_off":           "pl_osc_off",
+	"payload_oscillation_on":            "pl_osc_on",
+	"payload_pause":                     "pl_paus",
+	"payload_press":                     "pl_prs",
+	"payload_reset":                     "pl_rst",
+	"payload_reset_humidity":            "pl_rst_hum",
+	"payload_reset_mode":                "pl_rst_mode",
+	"payload_reset_percentage":          "pl_rst_pct",
+	"payload_reset_preset_mode":         "pl_rst_pr_mode",
+	"payload_return_to_base":            "pl_ret",
+	"payload_start":                     "pl_strt",
+	"payload_stop":                      "pl_stop",
+	"payload_trigger":                   "pl_trig",
+	"payload_unlock":                    "pl_unlk",
+	"percentage_command_template":       "pct_cmd_tpl",
+	"percentage_command_topic":          "pct_cmd_t",
+	"percentage_state_topic":            "pct_stat_t",
+	"percentage_value_template":         "pct_val_tpl",
+	"platform":                          "p",
+	"position_closed":                   "pos_clsd",
+	"position_open":                     "pos_open",
+	"position_template":                 "pos_tpl",
+	"position_topic":                    "pos_t",
+	"power_command_template":            "pow_cmd_tpl",
+	"power_command_topic":               "pow_cmd_t",
+	"preset_mode_command_template":      "pr_mode_cmd_tpl",
+	"preset_mode_command_topic":         "pr_mode_cmd_t",
+	"preset_mode_state_topic":           "pr_mode_stat_t",
+	"preset_mode_value_template":        "pr_mode_val_tpl",
+	"preset_modes":                      "pr_modes",
+	"release_summary":                   "rel_s",
+	"release_url":                       "rel_u",
+	"reports_position":                  "pos",
+	"retain":                            "ret",
+	"send_command_topic":                "send_cmd_t",
+	"serial_number":                     "sn",
+	"set_fan_speed_topic":               "set_fan_spd_t",
+	"set_position_template":             "set_pos_tpl",
+	"set_position_topic":                "set_pos_t",
+	"source_type":                       "src_type",
+	"speed_range_max":                   "spd_rng_max",
+	"speed_range_min":                   "spd_rng_min",
+	"state_class":                       "stat_cla",
+	"state_closed":                      "stat_clsd",
+	"state_closing":                     "stat_closing",
+	"state_jammed":                      "stat_jam",
+	"state_locked":                      "stat_locked",
+	"state_locking":                     "stat_locking",
+	"state_off":                         "stat_off",
+	"state_on":                          "stat_on",
+	"state_open":                        "stat_open",
+	"state_opening":                     "stat_opening",
+	"state_stopped":                     "stat_stopped",
+	"state_topic":                       "stat_t",
+	"state_unlocked":                    "stat_unlocked",
+	"state_unlocking":                   "stat_unlocking",
+	"state_value_template":              "stat_val_tpl",
+	"subtype":                           "stype",
+	"suggested_area":                    "sa",
+	"suggested_display_precision":       "sug_dsp_prc",
+	"support_duration":                  "sup_dur",
+	"support_url":                       "url",
+	"support_volume_set":                "sup_vol",
+	"supported_color_modes":             "sup_clrm",
+	"supported_features":                "sup_feat",
+	"sw_version":                        "sw",
+	"swing_mode_command_template":       "swing_mode_cmd_tpl",
+	"swing_mode_command_topic":          "swing_mode_cmd_t",
+	"swing_mode_state_template":         "swing_mode_stat_tpl",
+	"swing_mode_state_topic":            "swing_mode_stat_t",
+	"target_humidity_command_template":  "hum_cmd_tpl",
+	"target_humidity_command_topic":     "hum_cmd_t",
+	"target_humidity_state_topic":       "hum_stat_t",
+	"temperature_command_template":      "temp_cmd_tpl",
+	"temperature_command_topic":         "temp_cmd_t",
+	"temperature_high_command_template": "temp_hi_cmd_tpl",
+	"temperature_high_command_topic":    "temp_hi_cmd_t",
+	"temperature_high_state_template":   "temp_hi_stat_tpl",
+	"temperature_high_state_topic":      "temp_hi_stat_t",
+	"temperature_low_command_template":  "temp_lo_cmd_tpl",
+	"temperature_low_command_topic":     "temp_lo_cmd_t",
+	"temperature_low_state_template":    "temp_lo_stat_tpl",
+	"temperature_low_state_topic":       "temp_lo_stat_t",
+	"temperature_state_template":        "temp_stat_tpl",
+	"temperature_state_topic":           "temp_stat_t",
+	"temperature_unit":                  "temp_unit",
+	"tilt_closed_value":                 "tilt_clsd_val",
+	"tilt_command_template":             "tilt_cmd_tpl",
+	"tilt_command_topic":                "tilt_cmd_t",
+	"tilt_opened_value":                 "tilt_opnd_val",
+	"tilt_optimistic":                   "tilt_opt",
+	"tilt_status_template":              "tilt_status_tpl",
+	"tilt_status_topic":                 "tilt_status_t",
+	"topic":                             "t",
+	"unique_id":                         "uniq_id",
+	"unit_of_measurement":               "unit_of_meas",
+	"url_template":                      "url_tpl",
+	"url_topic":                         "url_t",
+	"value_template":                    "val_tpl",
+}
+
+// expansions maps the abbreviations supported by Home Assistant back to the
+// full keys of a config.
+var expansions = func() map[string]string {
+	expanded := make(map[string]string, len(abbreviations))
+
+	for key, abbreviation := range abbreviations {
+		expanded[abbreviation] = key
+	}
+
+	return expanded
+}()
+
+// AbbreviatedConfig ensures the config of the entity is marshaled using the
+// abbreviated keys supported by Home Assistant and that its topics are
+// shortened using a base topic. This results in smaller config messages.
+func AbbreviatedConfig() DetailsOption {
+	return func(e *EntityDetails) *EntityDetails {
+		e.abbreviate = true
+
+		return e
+	}
+}
+
+// marshalConfig will marshal the given entity config to JSON. If the entity
+// details specify an abbreviated config, the keys and topics of the config are
+// abbreviated.
+func marshalConfig(entity any, details *EntityDetails) ([]byte, error) {
+	cfg, err := json.Marshal(entity)
+	if err != nil {
+		return nil, err
+	}
+
+	if details == nil || !details.abbreviate {
+		return cfg, nil
+	}
+
+	return abbreviateConfig(cfg, strings.TrimSuffix(generateTopic("", details), "/"))
+}
+
+// abbreviateConfig replaces the keys of the given config with their
+// abbreviations. If a base topic is given, any topics in the config that start
+// with the base topic are shortened to use the "~" shorthand.
+func abbreviateConfig(cfg []byte, base string) ([]byte, error) {
+	config, err := unmarshalConfigMap(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if base != "" {
+		shortenTopics(config, base)
+	}
+
+	abbreviated, err := json.Marshal(renameKeys(config, abbreviations))
+	if err != nil {
+		return nil, fmt.Errorf("abbreviate config: %w", err)
+	}
+
+	return abbreviated, nil
+}
+
+// ExpandConfig will expand a config that uses the abbreviated keys and "~"
+// base topic shorthand supported by Home Assistant back to a config with the
+// full keys and topics. Configs without abbreviations are returned unchanged
+// (though possibly reformatted).
+func ExpandConfig(cfg []byte) ([]byte, error) {
+	config, err := unmarshalConfigMap(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	expanded, err := json.Marshal(expandTopics(renameKeys(config, expansions)))
+	if err != nil {
+		return nil, fmt.Errorf("expand config: %w", err)
+	}
+
+	return expanded, nil
+}
+
+// unmarshalConfigMap unmarshals a config into a map, preserving numbers as-is.
+func unmarshalConfigMap(cfg []byte) (map[string]any, error) {
+	var config map[string]any
+
+	decoder := json.NewDecoder(bytes.NewReader(cfg))
+	decoder.UseNumber()
+
+	if err := decoder.Decode(&config); err != nil {
+		return nil, fmt.Errorf("unmarshal config: %w", err)
+	}
+
+	return config, nil
+}
+
+// renameKeys returns the given value with the keys of any maps, recursively,
+// renamed using the given mapping. Keys without a mapping are left as-is.
+func renameKeys(value any, mapping map[string]string) any {
+	switch value := value.(type) {
+	case map[string]any:
+		renamed := make(map[string]any, len(value))
+
+		for key, v := range value {
+			if newKey, found := mapping[key]; found {
+				key = newKey
+			}
+			// The keys of the components of a device config are the IDs of
+			// the components, which must be left as-is. Only the config of
+			// each component is renamed.
+			if components, ok := v.(map[string]any); ok && isComponentsKey(key) {
+				for id, component := range components {
+					components[id] = renameKeys(component, mapping)
+				}
+
+				renamed[key] = components
+
+				continue
+			}
+
+			renamed[key] = renameKeys(v, mapping)
+		}
+
+		return renamed
+	case []any:
+		for idx := range value {
+			value[idx] = renameKeys(value[idx], mapping)
+		}
+
+		return value
+	default:
+		return value
+	}
+}
+
+// shortenTopics replaces the base topic in any topics of the config with the
+// "~" shorthand, and adds the base topic to the config.
+func shortenTopics(config map[string]any, base string) {
+	var shortened bool
+
+	for key, value := range config {
+		topic, ok := value.(string)
+		if !ok || !isTopicKey(key) || !strings.HasPrefix(topic, base+"/") {
+			continue
+		}
+
+		config[key] = topicBase + strings.TrimPrefix(topic, base)
+		shortened = true
+	}
+
+	if shortened {
+		config[topicBase] = base
+	}
+}
+
+// expandTopics replaces the "~" shorthand in any topics with the base topic, in
+// the given value and any maps it contains.
+func expandTopics(value any) any {
+	switch value := value.(type) {
+	case map[string]any:
+		base, hasBase := value[topicBase].(string)
+		delete(value, topicBase)
+
+		for key, v := range value {
+			if topic, ok := v.(string); ok && hasBase && isTopicKey(key) {
+				switch {
+				case strings.HasPrefix(topic, topicBase):
+					v = base + strings.TrimPrefix(topic, topicBase)
+				case strings.HasSuffix(topic, topicBase):
+					v = strings.TrimSuffix(topic, topicBase) + base
+				}
+			}
+
+			value[key] = expandTopics(v)
+		}
+
+		return value
+	case []any:
+		for idx := range value {
+			value[idx] = expandTopics(value[idx])
+		}
+
+		return value
+	default:
+		return value
+	}
+}
+
+// isComponentsKey returns whether the given (full or abbreviated) config key is
+// for the components of a device config.
+func isComponentsKey(key string) bool {
+	return key == "components" || key == abbreviations["components"]
+}
+
+// isTopicKey returns whether the given (full) config key is for a topic.
+func isTopicKey(key string) bool {
+	return key == "topic" || strings.HasSuffix(key, "_topic")
+}
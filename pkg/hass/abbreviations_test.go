@@ -0,0 +1,152 @@
+// Copyright (c) 2024 Joshua Rich <joshua.rich@gmail.com>
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package hass
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestAbbreviateExpandConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      string
+		base        string
+		abbreviated string
+	}{
+		{
+			name: "base topic",
+			config: `{
+				"name": "Temperature",
+				"unique_id": "temperature",
+				"state_topic": "homeassistant/sensor/app/temperature/state",
+				"json_attributes_topic": "homeassistant/sensor/app/temperature/attributes",
+				"availability_topic": "other/availability",
+				"suggested_display_precision": 1
+			}`,
+			base: "homeassistant/sensor/app/temperature",
+			abbreviated: `{
+				"~": "homeassistant/sensor/app/temperature",
+				"name": "Temperature",
+				"uniq_id": "temperature",
+				"stat_t": "~/state",
+				"json_attr_t": "~/attributes",
+				"avty_t": "other/availability",
+				"sug_dsp_prc": 1
+			}`,
+		},
+		{
+			name: "nested device and origin",
+			config: `{
+				"name": "Switch",
+				"command_topic": "homeassistant/switch/app/switch/set",
+				"device": {
+					"name": "Device",
+					"identifiers": ["device"],
+					"manufacturer": "Manufacturer",
+					"model_id": "M1",
+					"connections": [["mac", "02:5b:26:a8:dc:12"]]
+				},
+				"origin": {
+					"name": "Origin",
+					"sw_version": "1.0",
+					"support_url": "https://example.com"
+				}
+			}`,
+			base: "homeassistant/switch/app/switch",
+			abbreviated: `{
+				"~": "homeassistant/switch/app/switch",
+				"name": "Switch",
+				"cmd_t": "~/set",
+				"dev": {
+					"name": "Device",
+					"ids": ["device"],
+					"mf": "Manufacturer",
+					"mdl_id": "M1",
+					"cns": [["mac", "02:5b:26:a8:dc:12"]]
+				},
+				"o": {
+					"name": "Origin",
+					"sw": "1.0",
+					"url": "https://example.com"
+				}
+			}`,
+		},
+		{
+			name: "device components",
+			config: `{
+				"device": {"name": "Device", "identifiers": ["device"]},
+				"origin": {"name": "Origin"},
+				"components": {
+					"icon": {
+						"platform": "sensor",
+						"unique_id": "icon",
+						"state_topic": "homeassistant/sensor/app/icon/state"
+					},
+					"name": {
+						"platform": "binary_sensor",
+						"unique_id": "name",
+						"device_class": "door"
+					}
+				}
+			}`,
+			abbreviated: `{
+				"dev": {"name": "Device", "ids": ["device"]},
+				"o": {"name": "Origin"},
+				"cmps": {
+					"icon": {
+						"p": "sensor",
+						"uniq_id": "icon",
+						"stat_t": "homeassistant/sensor/app/icon/state"
+					},
+					"name": {
+						"p": "binary_sensor",
+						"uniq_id": "name",
+						"dev_cla": "door"
+					}
+				}
+			}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			abbreviated, err := abbreviateConfig([]byte(tt.config), tt.base)
+			if err != nil {
+				t.Fatalf("abbreviateConfig() error = %v", err)
+			}
+
+			assertEqualJSON(t, "abbreviateConfig()", abbreviated, tt.abbreviated)
+
+			expanded, err := ExpandConfig(abbreviated)
+			if err != nil {
+				t.Fatalf("ExpandConfig() error = %v", err)
+			}
+
+			assertEqualJSON(t, "ExpandConfig()", expanded, tt.config)
+		})
+	}
+}
+
+// assertEqualJSON fails the test if the given JSON documents are not
+// equivalent.
+func assertEqualJSON(t *testing.T, name string, got []byte, want string) {
+	t.Helper()
+
+	var gotValue, wantValue any
+
+	if err := json.Unmarshal(got, &gotValue); err != nil {
+		t.Fatalf("%s returned invalid JSON: %v", name, err)
+	}
+
+	if err := json.Unmarshal([]byte(want), &wantValue); err != nil {
+		t.Fatalf("invalid test JSON: %v", err)
+	}
+
+	if !reflect.DeepEqual(gotValue, wantValue) {
+		t.Errorf("%s = %s, want %s", name, got, want)
+	}
+}
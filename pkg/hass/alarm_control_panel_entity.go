@@ -0,0 +1,319 @@
+// Copyright (c) 2024 Joshua Rich <joshua.rich@gmail.com>
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+//go:generate go run golang.org/x/tools/cmd/stringer -type=AlarmAction,AlarmState,AlarmCodeType -output alarm_control_panel_entity_generated.go -linecomment
+package hass
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	mqttapi "github.com/joshuar/go-hass-anything/v12/pkg/mqtt"
+)
+
+const (
+	AlarmActionArmHome         AlarmAction = iota // ARM_HOME
+	AlarmActionArmAway                            // ARM_AWAY
+	AlarmActionArmNight                           // ARM_NIGHT
+	AlarmActionArmVacation                        // ARM_VACATION
+	AlarmActionArmCustomBypass                    // ARM_CUSTOM_BYPASS
+	AlarmActionDisarm                             // DISARM
+	AlarmActionTrigger                            // TRIGGER
+)
+
+// AlarmAction is an action requested of an alarm control panel by Home
+// Assistant.
+type AlarmAction int
+
+const (
+	AlarmStateDisarmed          AlarmState = iota // disarmed
+	AlarmStateArmedHome                           // armed_home
+	AlarmStateArmedAway                           // armed_away
+	AlarmStateArmedNight                          // armed_night
+	AlarmStateArmedVacation                       // armed_vacation
+	AlarmStateArmedCustomBypass                   // armed_custom_bypass
+	AlarmStatePending                             // pending
+	AlarmStateTriggered                           // triggered
+	AlarmStateArming                              // arming
+	AlarmStateDisarming                           // disarming
+)
+
+// AlarmState is one of the states an alarm control panel can be in.
+type AlarmState int
+
+const (
+	AlarmCodeNumeric AlarmCodeType = iota // REMOTE_CODE
+	AlarmCodeText                         // REMOTE_CODE_TEXT
+)
+
+// AlarmCodeType is the type of code entered in Home Assistant to arm or disarm
+// an alarm control panel, which defines the keypad shown in the frontend.
+type AlarmCodeType int
+
+// alarmCodeTemplate is the command template used when a code verifier is
+// configured, so that the code entered in Home Assistant is passed along with
+// the action.
+const alarmCodeTemplate = `{{ {'action': action, 'code': code} | to_json }}`
+
+// AlarmCodeVerifier is a function that verifies the code entered in Home
+// Assistant for the given action. It should return true if the code is valid.
+type AlarmCodeVerifier func(action AlarmAction, code string) bool
+
+// AlarmCommand represents a command received for an alarm control panel
+// entity.
+type AlarmCommand struct {
+	// Code is the code entered in Home Assistant. If a code verifier is
+	// configured and a code is required for the action, it has already been
+	// verified.
+	Code string
+	// Action is the requested action.
+	Action AlarmAction
+}
+
+// AlarmControlPanelEntity represents an entity that controls an alarm. For more
+// details see https://www.home-assistant.io/integrations/alarm_control_panel.mqtt/
+//
+//nolint:lll
+type AlarmControlPanelEntity struct {
+	*EntityDetails
+	*EntityAvailability
+	*EntityCommand
+	*EntityState
+	*EntityAttributes
+	verifier               AlarmCodeVerifier
+	Code                   string   `json:"code,omitempty"`
+	PayloadArmHome         string   `json:"payload_arm_home,omitempty"`
+	PayloadArmAway         string   `json:"payload_arm_away,omitempty"`
+	PayloadArmNight        string   `json:"payload_arm_night,omitempty"`
+	PayloadArmVacation     string   `json:"payload_arm_vacation,omitempty"`
+	PayloadArmCustomBypass string   `json:"payload_arm_custom_bypass,omitempty"`
+	PayloadDisarm          string   `json:"payload_disarm,omitempty"`
+	PayloadTrigger         string   `json:"payload_trigger,omitempty"`
+	SupportedFeatures      []string `json:"supported_features,omitempty" validate:"omitempty,unique"`
+	CodeArmRequired        bool     `json:"code_arm_required"`
+	CodeDisarmRequired     bool     `json:"code_disarm_required"`
+	CodeTriggerRequired    bool     `json:"code_trigger_required"`
+}
+
+// WithSupportedActions sets the arm modes and whether triggering is supported
+// by the alarm control panel. Disarming is always supported. By default, all
+// actions are supported.
+func (e *AlarmControlPanelEntity) WithSupportedActions(actions ...AlarmAction) *AlarmControlPanelEntity {
+	e.SupportedFeatures = make([]string, 0, len(actions))
+
+	for _, action := range actions {
+		if action == AlarmActionDisarm {
+			continue
+		}
+
+		e.SupportedFeatures = append(e.SupportedFeatures, strings.ToLower(action.String()))
+	}
+
+	return e
+}
+
+// WithActionPayload sets the payload sent to the command topic for the given
+// action. By default, the payload for each action is its name in upper case
+// (i.e., "ARM_HOME", "DISARM", etc.).
+func (e *AlarmControlPanelEntity) WithActionPayload(action AlarmAction, payload string) *AlarmControlPanelEntity {
+	switch action {
+	case AlarmActionArmHome:
+		e.PayloadArmHome = payload
+	case AlarmActionArmAway:
+		e.PayloadArmAway = payload
+	case AlarmActionArmNight:
+		e.PayloadArmNight = payload
+	case AlarmActionArmVacation:
+		e.PayloadArmVacation = payload
+	case AlarmActionArmCustomBypass:
+		e.PayloadArmCustomBypass = payload
+	case AlarmActionDisarm:
+		e.PayloadDisarm = payload
+	case AlarmActionTrigger:
+		e.PayloadTrigger = payload
+	}
+
+	return e
+}
+
+// WithCodeVerifier configures the alarm control panel to require a code of the
+// given type to be entered in Home Assistant. The code is passed along with
+// each command and checked with the given verifier before the command is passed
+// to the command handler. Commands with an invalid code are logged and ignored.
+// Use WithCodeRequired to control which actions require a code.
+func (e *AlarmControlPanelEntity) WithCodeVerifier(codeType AlarmCodeType, verifier AlarmCodeVerifier) *AlarmControlPanelEntity {
+	e.Code = codeType.String()
+	e.verifier = verifier
+	e.defaultCommandTemplate()
+
+	return e
+}
+
+// WithCodeRequired sets whether a code is required to arm, disarm and trigger
+// the alarm control panel respectively. By default, a code is required for all
+// actions.
+func (e *AlarmControlPanelEntity) WithCodeRequired(arm, disarm, trigger bool) *AlarmControlPanelEntity {
+	e.CodeArmRequired = arm
+	e.CodeDisarmRequired = disarm
+	e.CodeTriggerRequired = trigger
+
+	return e
+}
+
+func (e *AlarmControlPanelEntity) WithDetails(options ...DetailsOption) *AlarmControlPanelEntity {
+	e.EntityDetails = WithDetails(AlarmControlPanel, options...)
+
+	return e
+}
+
+func (e *AlarmControlPanelEntity) WithState(options ...StateOption) *AlarmControlPanelEntity {
+	e.EntityState = WithStateOptions(options...)
+	e.StateTopic = generateTopic("state", e.EntityDetails)
+
+	return e
+}
+
+func (e *AlarmControlPanelEntity) WithCommand(options ...CommandOption) *AlarmControlPanelEntity {
+	e.EntityCommand = WithCommandOptions(options...)
+	e.CommandTopic = generateTopic("set", e.EntityDetails)
+	e.defaultCommandTemplate()
+
+	return e
+}
+
+// defaultCommandTemplate ensures that, if codes are verified locally, the code
+// is sent along with the action so that it can be decoded. Any command template
+// that has been set is kept.
+func (e *AlarmControlPanelEntity) defaultCommandTemplate() {
+	if e.verifier != nil && e.EntityCommand != nil && e.CommandTemplate == "" {
+		e.CommandTemplate = alarmCodeTemplate
+	}
+}
+
+// WithCommandHandler configures the command of the alarm control panel to pass
+// each command received, decoded as an AlarmCommand, to the given handler. If a
+// code verifier has been configured, the code is verified before the handler is
+// called. Commands that do not match any of the configured action payloads or
+// that have an invalid code are logged and ignored.
+func (e *AlarmControlPanelEntity) WithCommandHandler(handler func(cmd *AlarmCommand), options ...CommandOption) *AlarmControlPanelEntity {
+	options = append(options, CommandCallback(decodeCommand(e.decodeCommand, handler)))
+
+	return e.WithCommand(options...)
+}
+
+func (e *AlarmControlPanelEntity) WithAttributes(options ...AttributeOption) *AlarmControlPanelEntity {
+	e.EntityAttributes = WithAttributesOptions(options...)
+	e.AttributesTopic = generateTopic("attributes", e.EntityDetails)
+
+	return e
+}
+
+// WithAvailability configures the topics on which the availability of the
+// entity is reported.
+func (e *AlarmControlPanelEntity) WithAvailability(options ...AvailabilityOption) *AlarmControlPanelEntity {
+	e.EntityAvailability = WithAvailabilityOptions(options...)
+
+	return e
+}
+
+// MarshalAlarmState will generate an *mqtt.Msg for the given alarm state, that
+// can be used to publish the state of the alarm control panel.
+func (e *AlarmControlPanelEntity) MarshalAlarmState(state AlarmState) (*mqttapi.Msg, error) {
+	if e.EntityState == nil {
+		return nil, fmt.Errorf("could not marshal state: %w", ErrNoStateTopic)
+	}
+
+	return mqttapi.NewMsg(e.StateTopic, []byte(state.String())), nil
+}
+
+func (e *AlarmControlPanelEntity) MarshalConfig() (*mqttapi.Msg, error) {
+	var (
+		cfg []byte
+		err error
+	)
+
+	if err = validateEntity(e); err != nil {
+		return nil, fmt.Errorf("entity config is invalid: %w", err)
+	}
+
+	configTopic := discoveryTopic(e.EntityDetails)
+
+	if cfg, err = marshalConfig(e, e.EntityDetails); err != nil {
+		return nil, fmt.Errorf("marshal config: %w", err)
+	}
+
+	return mqttapi.NewMsg(configTopic, cfg), nil
+}
+
+// decodeCommand converts a command payload into an AlarmCommand. The payload is
+// either the action payload on its own or, if a code is passed along, a JSON
+// object containing the action payload and code. If a code verifier is
+// configured and the action requires a code, the code is verified.
+//
+//nolint:cyclop
+func (e *AlarmControlPanelEntity) decodeCommand(payload []byte) (*AlarmCommand, error) {
+	var (
+		command  = &AlarmCommand{}
+		withCode struct {
+			Action string `json:"action"`
+			Code   string `json:"code"`
+		}
+		action = string(payload)
+	)
+
+	if err := json.Unmarshal(payload, &withCode); err == nil && withCode.Action != "" {
+		action = withCode.Action
+		command.Code = withCode.Code
+	}
+
+	switch action {
+	case payloadOrDefault(e.PayloadArmHome, AlarmActionArmHome.String()):
+		command.Action = AlarmActionArmHome
+	case payloadOrDefault(e.PayloadArmAway, AlarmActionArmAway.String()):
+		command.Action = AlarmActionArmAway
+	case payloadOrDefault(e.PayloadArmNight, AlarmActionArmNight.String()):
+		command.Action = AlarmActionArmNight
+	case payloadOrDefault(e.PayloadArmVacation, AlarmActionArmVacation.String()):
+		command.Action = AlarmActionArmVacation
+	case payloadOrDefault(e.PayloadArmCustomBypass, AlarmActionArmCustomBypass.String()):
+		command.Action = AlarmActionArmCustomBypass
+	case payloadOrDefault(e.PayloadDisarm, AlarmActionDisarm.String()):
+		command.Action = AlarmActionDisarm
+	case payloadOrDefault(e.PayloadTrigger, AlarmActionTrigger.String()):
+		command.Action = AlarmActionTrigger
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownPayload, action)
+	}
+
+	if e.verifier != nil && e.codeRequired(command.Action) && !e.verifier(command.Action, command.Code) {
+		return nil, fmt.Errorf("%w: for action %s", ErrInvalidCode, command.Action)
+	}
+
+	return command, nil
+}
+
+// codeRequired returns whether a code is required for the given action.
+func (e *AlarmControlPanelEntity) codeRequired(action AlarmAction) bool {
+	switch action {
+	case AlarmActionDisarm:
+		return e.CodeDisarmRequired
+	case AlarmActionTrigger:
+		return e.CodeTriggerRequired
+	default:
+		return e.CodeArmRequired
+	}
+}
+
+// NewAlarmControlPanelEntity creates a new alarm control panel entity. Like
+// Home Assistant, it defaults to requiring a code for all actions, if a code
+// verifier is configured.
+func NewAlarmControlPanelEntity() *AlarmControlPanelEntity {
+	return &AlarmControlPanelEntity{
+		CodeArmRequired:     true,
+		CodeDisarmRequired:  true,
+		CodeTriggerRequired: true,
+	}
+}
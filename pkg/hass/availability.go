@@ -0,0 +1,163 @@
+// Copyright (c) 2024 Joshua Rich <joshua.rich@gmail.com>
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package hass
+
+import (
+	"strings"
+
+	mqttapi "github.com/joshuar/go-hass-anything/v12/pkg/mqtt"
+)
+
+const (
+	// PayloadAvailable is the default payload that represents an available
+	// state.
+	PayloadAvailable = "online"
+	// PayloadNotAvailable is the default payload that represents an
+	// unavailable state.
+	PayloadNotAvailable = "offline"
+)
+
+// Availability represents a topic on which the availability of an entity is
+// reported.
+type Availability struct {
+	Topic               string `json:"topic" validate:"required"`
+	ValueTemplate       string `json:"value_template,omitempty"`
+	PayloadAvailable    string `json:"payload_available,omitempty"`
+	PayloadNotAvailable string `json:"payload_not_available,omitempty"`
+}
+
+// EntityAvailability represents the fields used by entities that can be
+// marked as unavailable. An entity can have multiple availability topics, and
+// the availability mode controls how they are combined to determine whether
+// the entity is available. Alternatively, a single availability topic can be
+// set with AvailabilityTopic and the related fields, but not both.
+//
+//nolint:lll
+type EntityAvailability struct {
+	Availability         []*Availability `json:"availability,omitempty" validate:"required_without=AvailabilityTopic,excluded_with=AvailabilityTopic,dive"`
+	AvailabilityMode     string          `json:"availability_mode,omitempty" validate:"omitempty,oneof=all any latest"`
+	AvailabilityTopic    string          `json:"availability_topic,omitempty"`
+	AvailabilityTemplate string          `json:"availability_template,omitempty"`
+	PayloadAvailable     string          `json:"payload_available,omitempty"`
+	PayloadNotAvailable  string          `json:"payload_not_available,omitempty"`
+}
+
+// AvailabilityOption is used to add availability topics to an entity or
+// configure how they are combined.
+type AvailabilityOption func(*EntityAvailability) *EntityAvailability
+
+// WithAvailabilityOptions will assign all of the passed in options to the
+// EntityAvailability.
+func WithAvailabilityOptions(options ...AvailabilityOption) *EntityAvailability {
+	availability := &EntityAvailability{}
+
+	for _, option := range options {
+		availability = option(availability)
+	}
+
+	return availability
+}
+
+// AvailabilityTopic adds the given topic as an availability topic of the
+// entity, using the default online/offline payloads.
+func AvailabilityTopic(topic string) AvailabilityOption {
+	return func(e *EntityAvailability) *EntityAvailability {
+		e.Availability = append(e.Availability, &Availability{Topic: topic})
+
+		return e
+	}
+}
+
+// CustomAvailability adds the given availability topic to the entity. This can
+// be used where the topic uses a template or payloads other than
+// online/offline.
+func CustomAvailability(availability *Availability) AvailabilityOption {
+	return func(e *EntityAvailability) *EntityAvailability {
+		e.Availability = append(e.Availability, availability)
+
+		return e
+	}
+}
+
+// AppAvailability adds the availability topics of the given app and of the
+// agent to the entity, which is then only available when both are online. The
+// agent marks the app topic online when the app is running and offline when it
+// stops or fails. The agent topic is marked offline by the MQTT broker if the
+// agent disconnects unexpectedly.
+func AppAvailability(app string) AvailabilityOption {
+	return func(e *EntityAvailability) *EntityAvailability {
+		e = AvailabilityTopic(AgentAvailabilityTopic())(e)
+		e = AvailabilityTopic(AppAvailabilityTopic(app))(e)
+
+		return AvailabilityModeAll()(e)
+	}
+}
+
+// AvailabilityModeAll ensures the entity is only available when all of its
+// availability topics are online.
+func AvailabilityModeAll() AvailabilityOption {
+	return func(e *EntityAvailability) *EntityAvailability {
+		e.AvailabilityMode = "all"
+
+		return e
+	}
+}
+
+// AvailabilityModeAny ensures the entity is available when any of its
+// availability topics are online.
+func AvailabilityModeAny() AvailabilityOption {
+	return func(e *EntityAvailability) *EntityAvailability {
+		e.AvailabilityMode = "any"
+
+		return e
+	}
+}
+
+// AvailabilityModeLatest ensures the availability of the entity follows the
+// last message received on any of its availability topics. This is the
+// default.
+func AvailabilityModeLatest() AvailabilityOption {
+	return func(e *EntityAvailability) *EntityAvailability {
+		e.AvailabilityMode = "latest"
+
+		return e
+	}
+}
+
+// AgentAvailabilityTopic returns the topic on which the availability of the
+// agent is reported.
+func AgentAvailabilityTopic() string {
+	return strings.Join([]string{HomeAssistantTopic, "go_hass_anything", "availability"}, "/")
+}
+
+// AppAvailabilityTopic returns the topic on which the availability of the
+// given app is reported.
+func AppAvailabilityTopic(app string) string {
+	return strings.Join([]string{HomeAssistantTopic, appID(app), "availability"}, "/")
+}
+
+// MarshalAppAvailability will generate an *mqtt.Msg that can be used to mark
+// the given app as available or unavailable. The message is retained, so that
+// Home Assistant will receive the availability of the app when it restarts.
+func MarshalAppAvailability(app string, available bool) *mqttapi.Msg {
+	return marshalAvailability(AppAvailabilityTopic(app), available)
+}
+
+// MarshalAgentAvailability will generate an *mqtt.Msg that can be used to mark
+// the agent as available or unavailable. Like MarshalAppAvailability, the
+// message is retained.
+func MarshalAgentAvailability(available bool) *mqttapi.Msg {
+	return marshalAvailability(AgentAvailabilityTopic(), available)
+}
+
+func marshalAvailability(topic string, available bool) *mqttapi.Msg {
+	payload := PayloadNotAvailable
+	if available {
+		payload = PayloadAvailable
+	}
+
+	return mqttapi.NewMsg(topic, []byte(payload)).Retain()
+}
@@ -7,7 +7,6 @@
 package hass
 
 import (
-	"encoding/json"
 	"fmt"
 
 	mqttapi "github.com/joshuar/go-hass-anything/v12/pkg/mqtt"
@@ -27,6 +26,7 @@ type ButtonType int
 // https://www.home-assistant.io/integrations/button.mqtt/
 type ButtonEntity struct {
 	*EntityDetails
+	*EntityAvailability
 	*EntityCommand
 	*EntityAttributes
 	ButtonType   string `json:"device_class,omitempty"`
@@ -70,6 +70,14 @@ func (e *ButtonEntity) WithAttributes(options ...AttributeOption) *ButtonEntity
 	return e
 }
 
+// WithAvailability configures the topics on which the availability of the
+// entity is reported.
+func (e *ButtonEntity) WithAvailability(options ...AvailabilityOption) *ButtonEntity {
+	e.EntityAvailability = WithAvailabilityOptions(options...)
+
+	return e
+}
+
 func (e *ButtonEntity) MarshalConfig() (*mqttapi.Msg, error) {
 	var (
 		cfg []byte
@@ -80,9 +88,9 @@ func (e *ButtonEntity) MarshalConfig() (*mqttapi.Msg, error) {
 		return nil, fmt.Errorf("entity config is invalid: %w", err)
 	}
 
-	configTopic := generateTopic("config", e.EntityDetails)
+	configTopic := discoveryTopic(e.EntityDetails)
 
-	if cfg, err = json.Marshal(e); err != nil {
+	if cfg, err = marshalConfig(e, e.EntityDetails); err != nil {
 		return nil, fmt.Errorf("marshal config: %w", err)
 	}
 
@@ -6,7 +6,6 @@
 package hass
 
 import (
-	"encoding/json"
 	"fmt"
 
 	mqttapi "github.com/joshuar/go-hass-anything/v12/pkg/mqtt"
@@ -16,6 +15,7 @@ import (
 // more details, see https://www.home-assistant.io/integrations/camera.mqtt/
 type CameraEntity struct {
 	*EntityDetails
+	*EntityAvailability
 	*EntityAttributes
 	*EntityEncoding
 	Topic string `json:"topic" validate:"required"`
@@ -40,6 +40,14 @@ func (e *CameraEntity) WithAttributes(options ...AttributeOption) *CameraEntity
 	return e
 }
 
+// WithAvailability configures the topics on which the availability of the
+// entity is reported.
+func (e *CameraEntity) WithAvailability(options ...AvailabilityOption) *CameraEntity {
+	e.EntityAvailability = WithAvailabilityOptions(options...)
+
+	return e
+}
+
 func (e *CameraEntity) MarshalConfig() (*mqttapi.Msg, error) {
 	var (
 		cfg []byte
@@ -52,9 +60,9 @@ func (e *CameraEntity) MarshalConfig() (*mqttapi.Msg, error) {
 		return nil, fmt.Errorf("entity config is invalid: %w", err)
 	}
 
-	configTopic := generateTopic("config", e.EntityDetails)
+	configTopic := discoveryTopic(e.EntityDetails)
 
-	if cfg, err = json.Marshal(e); err != nil {
+	if cfg, err = marshalConfig(e, e.EntityDetails); err != nil {
 		return nil, fmt.Errorf("marshal config: %w", err)
 	}
 
@@ -0,0 +1,83 @@
+// Copyright (c) 2024 Joshua Rich <joshua.rich@gmail.com>
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package hass
+
+import (
+	"bytes"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// changesGeneration is incremented by ResetChanges to invalidate the payloads
+// recorded by all change caches.
+var changesGeneration atomic.Uint64
+
+// ResetChanges forgets the payloads last published by all entities that only
+// publish on change, so that their next states and attributes are published
+// regardless. It should be called when Home Assistant comes online, as it will
+// not have any states published while it was offline.
+func ResetChanges() {
+	changesGeneration.Add(1)
+}
+
+// changeCache records the last payload published for a state or attributes, so
+// that identical payloads can be suppressed.
+type changeCache struct {
+	published  time.Time
+	payload    []byte
+	maxAge     time.Duration
+	generation uint64
+	mu         sync.Mutex
+}
+
+// changed returns whether the given payload should be published, because it
+// differs from the last payload published or the last payload is older than
+// the max age. If so, the payload is recorded as the last payload published.
+func (c *changeCache) changed(payload []byte) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	generation := changesGeneration.Load()
+
+	if c.payload != nil && c.generation == generation && bytes.Equal(c.payload, payload) &&
+		(c.maxAge == 0 || time.Since(c.published) < c.maxAge) {
+		return false
+	}
+
+	c.payload = bytes.Clone(payload)
+	c.published = time.Now()
+	c.generation = generation
+
+	return true
+}
+
+// OnlyOnChange ensures that the state of the entity is only marshaled when it
+// differs from the state last marshaled. Otherwise, MarshalState will return
+// ErrUnchanged. If maxAge is greater than zero, the state will be marshaled
+// regardless once it has not been for that long, so that Home Assistant still
+// receives regular updates. The states of sensors with forced updates are
+// always marshaled.
+func OnlyOnChange(maxAge time.Duration) StateOption {
+	return func(e *EntityState) *EntityState {
+		e.changes = &changeCache{maxAge: maxAge}
+
+		return e
+	}
+}
+
+// AttributesOnlyOnChange ensures that the attributes of the entity are only
+// marshaled when they differ from the attributes last marshaled. Otherwise,
+// MarshalAttributes will return ErrUnchanged. If maxAge is greater than zero,
+// the attributes will be marshaled regardless once they have not been for that
+// long.
+func AttributesOnlyOnChange(maxAge time.Duration) AttributeOption {
+	return func(e *EntityAttributes) *EntityAttributes {
+		e.changes = &changeCache{maxAge: maxAge}
+
+		return e
+	}
+}
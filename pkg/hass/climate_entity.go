@@ -0,0 +1,378 @@
+// Copyright (c) 2024 Joshua Rich <joshua.rich@gmail.com>
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+//go:generate go run golang.org/x/tools/cmd/stringer -type=HVACMode -output climate_entity_generated.go -linecomment
+package hass
+
+import (
+	"fmt"
+
+	mqttapi "github.com/joshuar/go-hass-anything/v12/pkg/mqtt"
+)
+
+const (
+	HVACModeAuto     HVACMode = iota // auto
+	HVACModeOff                      // off
+	HVACModeCool                     // cool
+	HVACModeHeat                     // heat
+	HVACModeDry                      // dry
+	HVACModeFanOnly                  // fan_only
+	HVACModeHeatCool                 // heat_cool
+)
+
+// HVACMode is an operating mode of a climate entity.
+type HVACMode int
+
+// HVACModes sets the list of HVAC modes supported by a climate entity. It can
+// be used in place of ModeList when configuring the modes of a climate entity.
+func HVACModes(modes ...HVACMode) ModeOption {
+	list := make([]string, 0, len(modes))
+
+	for _, mode := range modes {
+		list = append(list, mode.String())
+	}
+
+	return ModeList(list...)
+}
+
+// ClimateEntity represents an entity that controls a heating, ventilation or
+// air conditioning device. Each of the properties that can be controlled or
+// reported has its own command and state topic. For more details see
+// https://www.home-assistant.io/integrations/climate.mqtt/
+//
+//nolint:lll
+type ClimateEntity struct {
+	*EntityDetails
+	*EntityAvailability
+	*EntityAttributes
+	*EntityModes
+	*EntityPresetModes
+	EntityCommands `json:"-"`
+	EntityStates   `json:"-"`
+
+	TemperatureCommandTopic        string `json:"temperature_command_topic,omitempty"`
+	TemperatureCommandTemplate     string `json:"temperature_command_template,omitempty"`
+	TemperatureStateTopic          string `json:"temperature_state_topic,omitempty"`
+	TemperatureStateTemplate       string `json:"temperature_state_template,omitempty"`
+	TemperatureHighCommandTopic    string `json:"temperature_high_command_topic,omitempty"`
+	TemperatureHighCommandTemplate string `json:"temperature_high_command_template,omitempty"`
+	TemperatureHighStateTopic      string `json:"temperature_high_state_topic,omitempty"`
+	TemperatureHighStateTemplate   string `json:"temperature_high_state_template,omitempty"`
+	TemperatureLowCommandTopic     string `json:"temperature_low_command_topic,omitempty"`
+	TemperatureLowCommandTemplate  string `json:"temperature_low_command_template,omitempty"`
+	TemperatureLowStateTopic       string `json:"temperature_low_state_topic,omitempty"`
+	TemperatureLowStateTemplate    string `json:"temperature_low_state_template,omitempty"`
+
+	FanModes               []string `json:"fan_modes,omitempty"`
+	FanModeCommandTopic    string   `json:"fan_mode_command_topic,omitempty"`
+	FanModeCommandTemplate string   `json:"fan_mode_command_template,omitempty"`
+	FanModeStateTopic      string   `json:"fan_mode_state_topic,omitempty"`
+	FanModeStateTemplate   string   `json:"fan_mode_state_template,omitempty"`
+
+	SwingModes               []string `json:"swing_modes,omitempty"`
+	SwingModeCommandTopic    string   `json:"swing_mode_command_topic,omitempty"`
+	SwingModeCommandTemplate string   `json:"swing_mode_command_template,omitempty"`
+	SwingModeStateTopic      string   `json:"swing_mode_state_topic,omitempty"`
+	SwingModeStateTemplate   string   `json:"swing_mode_state_template,omitempty"`
+
+	CurrentTemperatureTopic    string `json:"current_temperature_topic,omitempty"`
+	CurrentTemperatureTemplate string `json:"current_temperature_template,omitempty"`
+	CurrentHumidityTopic       string `json:"current_humidity_topic,omitempty"`
+	CurrentHumidityTemplate    string `json:"current_humidity_template,omitempty"`
+
+	TargetHumidityCommandTopic    string `json:"target_humidity_command_topic,omitempty"`
+	TargetHumidityCommandTemplate string `json:"target_humidity_command_template,omitempty"`
+	TargetHumidityStateTopic      string `json:"target_humidity_state_topic,omitempty"`
+	TargetHumidityStateTemplate   string `json:"target_humidity_state_template,omitempty"`
+
+	ActionTopic    string `json:"action_topic,omitempty"`
+	ActionTemplate string `json:"action_template,omitempty"`
+
+	PowerCommandTopic    string `json:"power_command_topic,omitempty"`
+	PowerCommandTemplate string `json:"power_command_template,omitempty"`
+
+	TemperatureUnit string  `json:"temperature_unit,omitempty" validate:"omitempty,oneof=C F"`
+	MinTemp         float64 `json:"min_temp,omitempty"`
+	MaxTemp         float64 `json:"max_temp,omitempty" validate:"omitempty,gtfield=MinTemp"`
+	TempStep        float64 `json:"temp_step,omitempty" validate:"omitempty,gt=0"`
+	Precision       float64 `json:"precision,omitempty" validate:"omitempty,gt=0"`
+	MinHumidity     float64 `json:"min_humidity,omitempty" validate:"omitempty,gte=0"`
+	MaxHumidity     float64 `json:"max_humidity,omitempty" validate:"omitempty,lte=100,gtfield=MinHumidity"`
+	Optimistic      bool    `json:"optimistic,omitempty"`
+	Retain          bool    `json:"retain,omitempty"`
+}
+
+// OptimisticMode ensures the climate entity works in optimistic mode.
+func (e *ClimateEntity) OptimisticMode() *ClimateEntity {
+	e.Optimistic = true
+
+	return e
+}
+
+// RetainCommands ensures that Home Assistant publishes the commands of the
+// climate entity as retained messages, so that the last command is received on
+// (re)subscribing.
+func (e *ClimateEntity) RetainCommands() *ClimateEntity {
+	e.Retain = true
+
+	return e
+}
+
+// WithModes configures the HVAC modes supported by the entity, and the command
+// and state used to set and report the current HVAC mode. If no list of modes
+// is given, Home Assistant will assume all modes are supported.
+func (e *ClimateEntity) WithModes(options ...ModeOption) *ClimateEntity {
+	e.EntityModes = newEntityModes(e.EntityDetails, &e.EntityCommands, &e.EntityStates, options...)
+
+	return e
+}
+
+// WithTemperatureCommand configures the command used to set the (single)
+// target temperature.
+func (e *ClimateEntity) WithTemperatureCommand(options ...CommandOption) *ClimateEntity {
+	command := e.addCommand("temperature", e.EntityDetails, options...)
+	e.TemperatureCommandTopic = command.CommandTopic
+	e.TemperatureCommandTemplate = command.CommandTemplate
+
+	return e
+}
+
+// WithTemperatureState configures the state used to report the (single)
+// target temperature.
+func (e *ClimateEntity) WithTemperatureState(options ...StateOption) *ClimateEntity {
+	state := e.addState("temperature", e.EntityDetails, options...)
+	e.TemperatureStateTopic = state.StateTopic
+	e.TemperatureStateTemplate = state.ValueTemplate
+
+	return e
+}
+
+// WithTemperatureHighCommand configures the command used to set the upper
+// target temperature, for entities that support a target temperature range.
+func (e *ClimateEntity) WithTemperatureHighCommand(options ...CommandOption) *ClimateEntity {
+	command := e.addCommand("temperature_high", e.EntityDetails, options...)
+	e.TemperatureHighCommandTopic = command.CommandTopic
+	e.TemperatureHighCommandTemplate = command.CommandTemplate
+
+	return e
+}
+
+// WithTemperatureHighState configures the state used to report the upper
+// target temperature, for entities that support a target temperature range.
+func (e *ClimateEntity) WithTemperatureHighState(options ...StateOption) *ClimateEntity {
+	state := e.addState("temperature_high", e.EntityDetails, options...)
+	e.TemperatureHighStateTopic = state.StateTopic
+	e.TemperatureHighStateTemplate = state.ValueTemplate
+
+	return e
+}
+
+// WithTemperatureLowCommand configures the command used to set the lower
+// target temperature, for entities that support a target temperature range.
+func (e *ClimateEntity) WithTemperatureLowCommand(options ...CommandOption) *ClimateEntity {
+	command := e.addCommand("temperature_low", e.EntityDetails, options...)
+	e.TemperatureLowCommandTopic = command.CommandTopic
+	e.TemperatureLowCommandTemplate = command.CommandTemplate
+
+	return e
+}
+
+// WithTemperatureLowState configures the state used to report the lower
+// target temperature, for entities that support a target temperature range.
+func (e *ClimateEntity) WithTemperatureLowState(options ...StateOption) *ClimateEntity {
+	state := e.addState("temperature_low", e.EntityDetails, options...)
+	e.TemperatureLowStateTopic = state.StateTopic
+	e.TemperatureLowStateTemplate = state.ValueTemplate
+
+	return e
+}
+
+// WithFanModes configures the fan modes supported by the entity, and the
+// command and state used to set and report the current fan mode.
+func (e *ClimateEntity) WithFanModes(options ...ModeOption) *ClimateEntity {
+	modes, command, state := withModes("fan_mode", e.EntityDetails, &e.EntityCommands, &e.EntityStates, options...)
+	e.FanModes = modes
+
+	if command != nil {
+		e.FanModeCommandTopic = command.CommandTopic
+		e.FanModeCommandTemplate = command.CommandTemplate
+	}
+
+	if state != nil {
+		e.FanModeStateTopic = state.StateTopic
+		e.FanModeStateTemplate = state.ValueTemplate
+	}
+
+	return e
+}
+
+// WithSwingModes configures the swing modes supported by the entity, and the
+// command and state used to set and report the current swing mode.
+func (e *ClimateEntity) WithSwingModes(options ...ModeOption) *ClimateEntity {
+	modes, command, state := withModes("swing_mode", e.EntityDetails, &e.EntityCommands, &e.EntityStates, options...)
+	e.SwingModes = modes
+
+	if command != nil {
+		e.SwingModeCommandTopic = command.CommandTopic
+		e.SwingModeCommandTemplate = command.CommandTemplate
+	}
+
+	if state != nil {
+		e.SwingModeStateTopic = state.StateTopic
+		e.SwingModeStateTemplate = state.ValueTemplate
+	}
+
+	return e
+}
+
+// WithPresetModes configures the preset modes supported by the entity, and the
+// command and state used to set and report the current preset mode.
+func (e *ClimateEntity) WithPresetModes(options ...ModeOption) *ClimateEntity {
+	e.EntityPresetModes = newEntityPresetModes(e.EntityDetails, &e.EntityCommands, &e.EntityStates, options...)
+
+	return e
+}
+
+// WithCurrentTemperatureState configures the state used to report the
+// current (measured) temperature.
+func (e *ClimateEntity) WithCurrentTemperatureState(options ...StateOption) *ClimateEntity {
+	state := e.addState("current_temperature", e.EntityDetails, options...)
+	e.CurrentTemperatureTopic = state.StateTopic
+	e.CurrentTemperatureTemplate = state.ValueTemplate
+
+	return e
+}
+
+// WithCurrentHumidityState configures the state used to report the current
+// (measured) humidity.
+func (e *ClimateEntity) WithCurrentHumidityState(options ...StateOption) *ClimateEntity {
+	state := e.addState("current_humidity", e.EntityDetails, options...)
+	e.CurrentHumidityTopic = state.StateTopic
+	e.CurrentHumidityTemplate = state.ValueTemplate
+
+	return e
+}
+
+// WithTargetHumidityCommand configures the command used to set the target
+// humidity.
+func (e *ClimateEntity) WithTargetHumidityCommand(options ...CommandOption) *ClimateEntity {
+	command := e.addCommand("target_humidity", e.EntityDetails, options...)
+	e.TargetHumidityCommandTopic = command.CommandTopic
+	e.TargetHumidityCommandTemplate = command.CommandTemplate
+
+	return e
+}
+
+// WithTargetHumidityState configures the state used to report the target
+// humidity.
+func (e *ClimateEntity) WithTargetHumidityState(options ...StateOption) *ClimateEntity {
+	state := e.addState("target_humidity", e.EntityDetails, options...)
+	e.TargetHumidityStateTopic = state.StateTopic
+	e.TargetHumidityStateTemplate = state.ValueTemplate
+
+	return e
+}
+
+// WithActionState configures the state used to report the current action of
+// the entity. Valid values are off, heating, cooling, drying, idle, fan,
+// preheating and defrosting.
+func (e *ClimateEntity) WithActionState(options ...StateOption) *ClimateEntity {
+	state := e.addState("action", e.EntityDetails, options...)
+	e.ActionTopic = state.StateTopic
+	e.ActionTemplate = state.ValueTemplate
+
+	return e
+}
+
+// WithPowerCommand configures the command used to turn the entity on or off.
+func (e *ClimateEntity) WithPowerCommand(options ...CommandOption) *ClimateEntity {
+	command := e.addCommand("power", e.EntityDetails, options...)
+	e.PowerCommandTopic = command.CommandTopic
+	e.PowerCommandTemplate = command.CommandTemplate
+
+	return e
+}
+
+// WithTemperatureRange sets the minimum and maximum target temperature and the
+// step size by which the target temperature can be changed.
+//
+//nolint:predeclared
+func (e *ClimateEntity) WithTemperatureRange(min, max, step float64) *ClimateEntity {
+	e.MinTemp = min
+	e.MaxTemp = max
+	e.TempStep = step
+
+	return e
+}
+
+// WithTemperatureUnit sets the unit of the temperatures used by the entity.
+// Must be either "C" or "F". Defaults to the unit system of Home Assistant.
+func (e *ClimateEntity) WithTemperatureUnit(unit string) *ClimateEntity {
+	e.TemperatureUnit = unit
+
+	return e
+}
+
+// WithPrecision sets the precision of the temperatures reported by the entity.
+// Must be one of 0.1, 0.5 or 1.
+func (e *ClimateEntity) WithPrecision(precision float64) *ClimateEntity {
+	e.Precision = precision
+
+	return e
+}
+
+// WithHumidityRange sets the minimum and maximum target humidity.
+//
+//nolint:predeclared
+func (e *ClimateEntity) WithHumidityRange(min, max float64) *ClimateEntity {
+	e.MinHumidity = min
+	e.MaxHumidity = max
+
+	return e
+}
+
+func (e *ClimateEntity) WithDetails(options ...DetailsOption) *ClimateEntity {
+	e.EntityDetails = WithDetails(Climate, options...)
+
+	return e
+}
+
+func (e *ClimateEntity) WithAttributes(options ...AttributeOption) *ClimateEntity {
+	e.EntityAttributes = WithAttributesOptions(options...)
+	e.AttributesTopic = generateTopic("attributes", e.EntityDetails)
+
+	return e
+}
+
+// WithAvailability configures the topics on which the availability of the
+// entity is reported.
+func (e *ClimateEntity) WithAvailability(options ...AvailabilityOption) *ClimateEntity {
+	e.EntityAvailability = WithAvailabilityOptions(options...)
+
+	return e
+}
+
+func (e *ClimateEntity) MarshalConfig() (*mqttapi.Msg, error) {
+	var (
+		cfg []byte
+		err error
+	)
+
+	if err = validateEntity(e); err != nil {
+		return nil, fmt.Errorf("entity config is invalid: %w", err)
+	}
+
+	configTopic := discoveryTopic(e.EntityDetails)
+
+	if cfg, err = marshalConfig(e, e.EntityDetails); err != nil {
+		return nil, fmt.Errorf("marshal config: %w", err)
+	}
+
+	return mqttapi.NewMsg(configTopic, cfg), nil
+}
+
+func NewClimateEntity() *ClimateEntity {
+	return &ClimateEntity{}
+}
@@ -0,0 +1,317 @@
+// Copyright (c) 2024 Joshua Rich <joshua.rich@gmail.com>
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+//go:generate go run golang.org/x/tools/cmd/stringer -type=CoverState,CoverType -output cover_entity_generated.go -linecomment
+package hass
+
+import (
+	"fmt"
+
+	mqttapi "github.com/joshuar/go-hass-anything/v12/pkg/mqtt"
+)
+
+const (
+	CoverStateOpen    CoverState = iota // open
+	CoverStateOpening                   // opening
+	CoverStateClosed                    // closed
+	CoverStateClosing                   // closing
+	CoverStateStopped                   // stopped
+)
+
+// CoverState is one of the states a cover can be in.
+type CoverState int
+
+const (
+	CoverTypeNone    CoverType = iota //
+	CoverTypeAwning                   // awning
+	CoverTypeBlind                    // blind
+	CoverTypeCurtain                  // curtain
+	CoverTypeDamper                   // damper
+	CoverTypeDoor                     // door
+	CoverTypeGarage                   // garage
+	CoverTypeGate                     // gate
+	CoverTypeShade                    // shade
+	CoverTypeShutter                  // shutter
+	CoverTypeWindow                   // window
+)
+
+// CoverType is the type of cover, which defines how it is displayed in Home
+// Assistant.
+type CoverType int
+
+// CoverEntity represents an entity that controls a cover, such as blinds or a
+// garage door. Covers have a command topic to open, close and stop the cover,
+// and optionally topics to set and report its position and tilt. For more
+// details see https://www.home-assistant.io/integrations/cover.mqtt/
+//
+//nolint:lll
+type CoverEntity struct {
+	*EntityDetails
+	*EntityAvailability
+	*EntityCommand
+	*EntityState
+	*EntityAttributes
+	EntityCommands      `json:"-"`
+	EntityStates        `json:"-"`
+	CoverType           string `json:"device_class,omitempty"`
+	PayloadOpen         string `json:"payload_open,omitempty"`
+	PayloadClose        string `json:"payload_close,omitempty"`
+	PayloadStop         string `json:"payload_stop,omitempty"`
+	StateOpen           string `json:"state_open,omitempty"`
+	StateOpening        string `json:"state_opening,omitempty"`
+	StateClosed         string `json:"state_closed,omitempty"`
+	StateClosing        string `json:"state_closing,omitempty"`
+	StateStopped        string `json:"state_stopped,omitempty"`
+	PositionTopic       string `json:"position_topic,omitempty"`
+	PositionTemplate    string `json:"position_template,omitempty"`
+	SetPositionTopic    string `json:"set_position_topic,omitempty"`
+	SetPositionTemplate string `json:"set_position_template,omitempty"`
+	TiltCommandTopic    string `json:"tilt_command_topic,omitempty"`
+	TiltCommandTemplate string `json:"tilt_command_template,omitempty"`
+	TiltStatusTopic     string `json:"tilt_status_topic,omitempty"`
+	TiltStatusTemplate  string `json:"tilt_status_template,omitempty"`
+	PositionOpen        *int   `json:"position_open,omitempty"`
+	PositionClosed      *int   `json:"position_closed,omitempty"`
+	TiltMin             *int   `json:"tilt_min,omitempty"`
+	TiltMax             *int   `json:"tilt_max,omitempty"`
+	TiltOpenedValue     *int   `json:"tilt_opened_value,omitempty"`
+	TiltClosedValue     *int   `json:"tilt_closed_value,omitempty"`
+	Optimistic          bool   `json:"optimistic,omitempty"`
+	TiltOptimistic      bool   `json:"tilt_optimistic,omitempty"`
+}
+
+// OptimisticMode ensures the cover works in optimistic mode.
+func (e *CoverEntity) OptimisticMode() *CoverEntity {
+	e.Optimistic = true
+
+	return e
+}
+
+// TiltOptimisticMode ensures the tilt of the cover works in optimistic mode.
+func (e *CoverEntity) TiltOptimisticMode() *CoverEntity {
+	e.TiltOptimistic = true
+
+	return e
+}
+
+// WithCoverType sets the type of cover, defining how it gets displayed in Home
+// Assistant. The DeviceClass state option cannot be used for covers. See also:
+// https://www.home-assistant.io/integrations/cover/#device-class
+func (e *CoverEntity) WithCoverType(coverType CoverType) *CoverEntity {
+	e.CoverType = coverType.String()
+
+	return e
+}
+
+// WithOpenPayload sets the payload sent to the command topic to open the
+// cover. Defaults to OPEN.
+func (e *CoverEntity) WithOpenPayload(payload string) *CoverEntity {
+	e.PayloadOpen = payload
+
+	return e
+}
+
+// WithClosePayload sets the payload sent to the command topic to close the
+// cover. Defaults to CLOSE.
+func (e *CoverEntity) WithClosePayload(payload string) *CoverEntity {
+	e.PayloadClose = payload
+
+	return e
+}
+
+// WithStopPayload sets the payload sent to the command topic to stop the
+// cover. Defaults to STOP.
+func (e *CoverEntity) WithStopPayload(payload string) *CoverEntity {
+	e.PayloadStop = payload
+
+	return e
+}
+
+// WithStatePayload sets the payload that represents the given state on the
+// state topic. By default, the payload for each state is its name (i.e.,
+// "open", "closing", etc.).
+func (e *CoverEntity) WithStatePayload(state CoverState, payload string) *CoverEntity {
+	switch state {
+	case CoverStateOpen:
+		e.StateOpen = payload
+	case CoverStateOpening:
+		e.StateOpening = payload
+	case CoverStateClosed:
+		e.StateClosed = payload
+	case CoverStateClosing:
+		e.StateClosing = payload
+	case CoverStateStopped:
+		e.StateStopped = payload
+	}
+
+	return e
+}
+
+// WithPositionRange sets the values that represent the fully closed and fully
+// open positions of the cover. Defaults to 0 and 100 respectively.
+func (e *CoverEntity) WithPositionRange(closed, open int) *CoverEntity {
+	e.PositionClosed = &closed
+	e.PositionOpen = &open
+
+	return e
+}
+
+// WithTiltRange sets the minimum and maximum tilt values of the cover, as well
+// as the values used when the tilt is opened or closed.
+//
+//nolint:predeclared
+func (e *CoverEntity) WithTiltRange(min, max, opened, closed int) *CoverEntity {
+	e.TiltMin = &min
+	e.TiltMax = &max
+	e.TiltOpenedValue = &opened
+	e.TiltClosedValue = &closed
+
+	return e
+}
+
+func (e *CoverEntity) WithDetails(options ...DetailsOption) *CoverEntity {
+	e.EntityDetails = WithDetails(Cover, options...)
+
+	return e
+}
+
+// WithState configures the state used to report whether the cover is open,
+// closed, opening, closing or stopped.
+func (e *CoverEntity) WithState(options ...StateOption) *CoverEntity {
+	e.EntityState = WithStateOptions(options...)
+	e.StateTopic = generateTopic("state", e.EntityDetails)
+
+	return e
+}
+
+// WithCommand configures the command used to open, close or stop the cover.
+func (e *CoverEntity) WithCommand(options ...CommandOption) *CoverEntity {
+	e.EntityCommand = WithCommandOptions(options...)
+	e.CommandTopic = generateTopic("set", e.EntityDetails)
+
+	return e
+}
+
+// WithPositionState configures the state used to report the position of the
+// cover.
+func (e *CoverEntity) WithPositionState(options ...StateOption) *CoverEntity {
+	state := e.addState("position", e.EntityDetails, options...)
+	e.PositionTopic = state.StateTopic
+	e.PositionTemplate = state.ValueTemplate
+
+	return e
+}
+
+// WithSetPositionCommand configures the command used to set the position of
+// the cover.
+func (e *CoverEntity) WithSetPositionCommand(options ...CommandOption) *CoverEntity {
+	command := e.addCommand("position", e.EntityDetails, options...)
+	e.SetPositionTopic = command.CommandTopic
+	e.SetPositionTemplate = command.CommandTemplate
+
+	return e
+}
+
+// WithTiltState configures the state used to report the tilt of the cover.
+func (e *CoverEntity) WithTiltState(options ...StateOption) *CoverEntity {
+	state := e.addState("tilt", e.EntityDetails, options...)
+	e.TiltStatusTopic = state.StateTopic
+	e.TiltStatusTemplate = state.ValueTemplate
+
+	return e
+}
+
+// WithTiltCommand configures the command used to set the tilt of the cover.
+func (e *CoverEntity) WithTiltCommand(options ...CommandOption) *CoverEntity {
+	command := e.addCommand("tilt", e.EntityDetails, options...)
+	e.TiltCommandTopic = command.CommandTopic
+	e.TiltCommandTemplate = command.CommandTemplate
+
+	return e
+}
+
+func (e *CoverEntity) WithAttributes(options ...AttributeOption) *CoverEntity {
+	e.EntityAttributes = WithAttributesOptions(options...)
+	e.AttributesTopic = generateTopic("attributes", e.EntityDetails)
+
+	return e
+}
+
+// WithAvailability configures the topics on which the availability of the
+// entity is reported.
+func (e *CoverEntity) WithAvailability(options ...AvailabilityOption) *CoverEntity {
+	e.EntityAvailability = WithAvailabilityOptions(options...)
+
+	return e
+}
+
+// MarshalCoverState will generate an *mqtt.Msg for the given cover state,
+// using the payload configured for that state, that can be used to publish the
+// state of the cover.
+func (e *CoverEntity) MarshalCoverState(state CoverState) (*mqttapi.Msg, error) {
+	if e.EntityState == nil {
+		return nil, fmt.Errorf("could not marshal state: %w", ErrNoStateTopic)
+	}
+
+	var payload string
+
+	switch state {
+	case CoverStateOpen:
+		payload = e.StateOpen
+	case CoverStateOpening:
+		payload = e.StateOpening
+	case CoverStateClosed:
+		payload = e.StateClosed
+	case CoverStateClosing:
+		payload = e.StateClosing
+	case CoverStateStopped:
+		payload = e.StateStopped
+	}
+
+	if payload == "" {
+		payload = state.String()
+	}
+
+	return mqttapi.NewMsg(e.StateTopic, []byte(payload)), nil
+}
+
+// MarshalStates will generate an *mqtt.Msg for the state of the cover and each
+// of its position and tilt states that have a state callback.
+func (e *CoverEntity) MarshalStates(args ...any) ([]*mqttapi.Msg, error) {
+	return marshalStates(e.EntityState, &e.EntityStates, args...)
+}
+
+// MarshalSubscriptions will generate an *mqtt.Subscription for the command
+// topic of the cover and each of its set position and tilt command topics.
+func (e *CoverEntity) MarshalSubscriptions() ([]*mqttapi.Subscription, error) {
+	return marshalSubscriptions(e.EntityCommand, &e.EntityCommands)
+}
+
+func (e *CoverEntity) MarshalConfig() (*mqttapi.Msg, error) {
+	var (
+		cfg []byte
+		err error
+	)
+
+	if err = validateEntity(e); err != nil {
+		return nil, fmt.Errorf("entity config is invalid: %w", err)
+	}
+
+	configTopic := discoveryTopic(e.EntityDetails)
+
+	if cfg, err = marshalConfig(e, e.EntityDetails); err != nil {
+		return nil, fmt.Errorf("marshal config: %w", err)
+	}
+
+	return mqttapi.NewMsg(configTopic, cfg), nil
+}
+
+func (e *CoverEntity) validate() error {
+	return validateNoStateDeviceClass(e.EntityState, "WithCoverType")
+}
+
+func NewCoverEntity() *CoverEntity {
+	return &CoverEntity{}
+}
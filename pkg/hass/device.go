@@ -0,0 +1,178 @@
+// Copyright (c) 2024 Joshua Rich <joshua.rich@gmail.com>
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+//go:generate go run golang.org/x/tools/cmd/stringer -type=ConnectionType -output device_generated.go -linecomment
+package hass
+
+import (
+	"encoding/json"
+)
+
+const (
+	ConnectionTypeMAC       ConnectionType = iota // mac
+	ConnectionTypeIP                              // ip
+	ConnectionTypeBluetooth                       // bluetooth
+	ConnectionTypeUPnP                            // upnp
+	ConnectionTypeZigbee                          // zigbee
+)
+
+// ConnectionType is the type of a connection of a device, such as its MAC
+// address.
+type ConnectionType int
+
+// Connection is a connection of a device to the outside world, such as its
+// MAC address. It is used by Home Assistant to match the device with the same
+// device from other integrations.
+type Connection struct {
+	Value string
+	Type  ConnectionType
+}
+
+// MarshalJSON marshals the connection as a [type, value] pair, as expected by
+// Home Assistant.
+func (c Connection) MarshalJSON() ([]byte, error) {
+	return json.Marshal([2]string{c.Type.String(), c.Value})
+}
+
+// Device contains information about the device an entity is a part of to tie it
+// into the device registry in Home Assistant.
+type Device struct {
+	Name          string       `json:"name"`
+	Manufacturer  string       `json:"manufacturer,omitempty"`
+	Model         string       `json:"model,omitempty"`
+	ModelID       string       `json:"model_id,omitempty"`
+	HWVersion     string       `json:"hw_version,omitempty"`
+	SWVersion     string       `json:"sw_version,omitempty"`
+	SerialNumber  string       `json:"serial_number,omitempty"`
+	URL           string       `json:"configuration_url,omitempty"`
+	SuggestedArea string       `json:"suggested_area,omitempty"`
+	ViaDevice     string       `json:"via_device,omitempty"`
+	Identifiers   []string     `json:"identifiers"`
+	Connections   []Connection `json:"connections,omitempty"`
+}
+
+// DeviceOption is used to set the info of a device.
+type DeviceOption func(*Device) *Device
+
+// NewDevice creates a new Device with the given name and options. A device
+// needs at least one identifier or connection.
+func NewDevice(name string, options ...DeviceOption) *Device {
+	device := &Device{
+		Name: name,
+	}
+
+	for _, option := range options {
+		device = option(device)
+	}
+
+	return device
+}
+
+// WithIdentifiers adds the given identifiers to the device.
+func WithIdentifiers(identifiers ...string) DeviceOption {
+	return func(d *Device) *Device {
+		d.Identifiers = append(d.Identifiers, identifiers...)
+
+		return d
+	}
+}
+
+// WithConnection adds a connection with the given type and value to the
+// device.
+func WithConnection(connectionType ConnectionType, value string) DeviceOption {
+	return func(d *Device) *Device {
+		d.Connections = append(d.Connections, Connection{Type: connectionType, Value: value})
+
+		return d
+	}
+}
+
+// WithManufacturer sets the manufacturer of the device.
+func WithManufacturer(manufacturer string) DeviceOption {
+	return func(d *Device) *Device {
+		d.Manufacturer = manufacturer
+
+		return d
+	}
+}
+
+// WithModel sets the model name and model ID (i.e., a part number) of the
+// device. Either can be empty.
+func WithModel(model, modelID string) DeviceOption {
+	return func(d *Device) *Device {
+		d.Model = model
+		d.ModelID = modelID
+
+		return d
+	}
+}
+
+// WithVersions sets the hardware and software (firmware) versions of the
+// device. Either can be empty.
+func WithVersions(hwVersion, swVersion string) DeviceOption {
+	return func(d *Device) *Device {
+		d.HWVersion = hwVersion
+		d.SWVersion = swVersion
+
+		return d
+	}
+}
+
+// WithSerialNumber sets the serial number of the device.
+func WithSerialNumber(serialNumber string) DeviceOption {
+	return func(d *Device) *Device {
+		d.SerialNumber = serialNumber
+
+		return d
+	}
+}
+
+// WithConfigurationURL sets a URL where the device can be configured, which is
+// linked to from the device page in Home Assistant.
+func WithConfigurationURL(url string) DeviceOption {
+	return func(d *Device) *Device {
+		d.URL = url
+
+		return d
+	}
+}
+
+// WithSuggestedArea sets the area that Home Assistant will suggest the device
+// is in, when it is first added.
+func WithSuggestedArea(area string) DeviceOption {
+	return func(d *Device) *Device {
+		d.SuggestedArea = area
+
+		return d
+	}
+}
+
+// WithViaDevice sets the identifier of a device that routes messages between
+// this device and Home Assistant, such as a hub or bridge.
+func WithViaDevice(identifier string) DeviceOption {
+	return func(d *Device) *Device {
+		d.ViaDevice = identifier
+
+		return d
+	}
+}
+
+// Origin contains information about the app that is responsible for the entity.
+// It is used by Home Assistant for logging and display purposes.
+type Origin struct {
+	Name    string `json:"name"`
+	Version string `json:"sw_version,omitempty"`
+	URL     string `json:"support_url,omitempty"`
+}
+
+// NewOrigin creates a new Origin with the given name, version and support URL.
+// The version and URL can be empty.
+func NewOrigin(name, version, url string) *Origin {
+	return &Origin{
+		Name:    name,
+		Version: version,
+		URL:     url,
+	}
+}
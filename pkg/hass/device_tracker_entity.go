@@ -0,0 +1,182 @@
+// Copyright (c) 2024 Joshua Rich <joshua.rich@gmail.com>
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+//go:generate go run golang.org/x/tools/cmd/stringer -type=SourceType -output device_tracker_entity_generated.go -linecomment
+package hass
+
+import (
+	"encoding/json"
+	"fmt"
+
+	mqttapi "github.com/joshuar/go-hass-anything/v12/pkg/mqtt"
+)
+
+const (
+	SourceTypeGPS         SourceType = iota // gps
+	SourceTypeRouter                        // router
+	SourceTypeBluetooth                     // bluetooth
+	SourceTypeBluetoothLE                   // bluetooth_le
+)
+
+// SourceType is the source of the location reported by a device tracker.
+type SourceType int
+
+const (
+	// DeviceTrackerHome is the default payload for the home state of a device
+	// tracker.
+	DeviceTrackerHome = "home"
+	// DeviceTrackerNotHome is the default payload for the not_home state of a
+	// device tracker.
+	DeviceTrackerNotHome = "not_home"
+)
+
+// DeviceTrackerLocation represents a GPS location reported by a device
+// tracker. It is published as the attributes of the device tracker, from which
+// Home Assistant will determine the zone the device is in.
+type DeviceTrackerLocation struct {
+	Latitude    float64 `json:"latitude" validate:"latitude"`
+	Longitude   float64 `json:"longitude" validate:"longitude"`
+	GPSAccuracy int     `json:"gps_accuracy,omitempty" validate:"gte=0"`
+}
+
+// DeviceTrackerEntity represents an entity that tracks the location of a
+// device, either as a state (home, not_home or the name of a zone) or as a GPS
+// location. For more details see
+// https://www.home-assistant.io/integrations/device_tracker.mqtt/
+type DeviceTrackerEntity struct {
+	*EntityDetails
+	*EntityAvailability
+	*EntityState
+	*EntityAttributes
+	PayloadHome    string `json:"payload_home,omitempty"`
+	PayloadNotHome string `json:"payload_not_home,omitempty"`
+	PayloadReset   string `json:"payload_reset,omitempty"`
+	SourceType     string `json:"source_type,omitempty"`
+}
+
+// WithSourceType sets the source of the location reported by the device
+// tracker.
+func (e *DeviceTrackerEntity) WithSourceType(sourceType SourceType) *DeviceTrackerEntity {
+	e.SourceType = sourceType.String()
+
+	return e
+}
+
+// WithHomePayloads sets the payloads that represent the home and not_home
+// states. Defaults to "home" and "not_home" respectively.
+func (e *DeviceTrackerEntity) WithHomePayloads(home, notHome string) *DeviceTrackerEntity {
+	e.PayloadHome = home
+	e.PayloadNotHome = notHome
+
+	return e
+}
+
+// WithResetPayload defines a special payload that resets the state to unknown
+// when received on the state_topic. Defaults to "None".
+func (e *DeviceTrackerEntity) WithResetPayload(payload string) *DeviceTrackerEntity {
+	e.PayloadReset = payload
+
+	return e
+}
+
+func (e *DeviceTrackerEntity) WithDetails(options ...DetailsOption) *DeviceTrackerEntity {
+	e.EntityDetails = WithDetails(DeviceTracker, options...)
+
+	return e
+}
+
+// WithState configures the state used to report whether the device is home,
+// not_home or in a named zone.
+func (e *DeviceTrackerEntity) WithState(options ...StateOption) *DeviceTrackerEntity {
+	e.EntityState = WithStateOptions(options...)
+	e.StateTopic = generateTopic("state", e.EntityDetails)
+
+	return e
+}
+
+// WithAttributes configures the attributes of the device tracker. The
+// attributes are also used to report a GPS location, see MarshalLocation.
+func (e *DeviceTrackerEntity) WithAttributes(options ...AttributeOption) *DeviceTrackerEntity {
+	e.EntityAttributes = WithAttributesOptions(options...)
+	e.AttributesTopic = generateTopic("attributes", e.EntityDetails)
+
+	return e
+}
+
+// WithAvailability configures the topics on which the availability of the
+// entity is reported.
+func (e *DeviceTrackerEntity) WithAvailability(options ...AvailabilityOption) *DeviceTrackerEntity {
+	e.EntityAvailability = WithAvailabilityOptions(options...)
+
+	return e
+}
+
+// MarshalHomeState will generate an *mqtt.Msg that can be used to publish
+// whether the device is home or not.
+func (e *DeviceTrackerEntity) MarshalHomeState(home bool) (*mqttapi.Msg, error) {
+	if home {
+		return e.MarshalZoneState(payloadOrDefault(e.PayloadHome, DeviceTrackerHome))
+	}
+
+	return e.MarshalZoneState(payloadOrDefault(e.PayloadNotHome, DeviceTrackerNotHome))
+}
+
+// MarshalZoneState will generate an *mqtt.Msg that can be used to publish the
+// name of the zone the device is in.
+func (e *DeviceTrackerEntity) MarshalZoneState(zone string) (*mqttapi.Msg, error) {
+	if e.EntityState == nil {
+		return nil, fmt.Errorf("could not marshal state: %w", ErrNoStateTopic)
+	}
+
+	return mqttapi.NewMsg(e.StateTopic, []byte(zone)), nil
+}
+
+// MarshalLocation will generate an *mqtt.Msg for the given location that can
+// be used to publish the GPS location of the device. The location is validated
+// before being marshaled. The device tracker must have been configured with
+// attributes.
+func (e *DeviceTrackerEntity) MarshalLocation(location *DeviceTrackerLocation) (*mqttapi.Msg, error) {
+	if e.EntityAttributes == nil {
+		return nil, fmt.Errorf("could not marshal location: %w", ErrNoAttributesTopic)
+	}
+
+	if err := validateEntity(location); err != nil {
+		return nil, fmt.Errorf("location is invalid: %w", err)
+	}
+
+	payload, err := json.Marshal(location)
+	if err != nil {
+		return nil, fmt.Errorf("could not marshal location: %w", err)
+	}
+
+	return mqttapi.NewMsg(e.AttributesTopic, payload), nil
+}
+
+func (e *DeviceTrackerEntity) MarshalConfig() (*mqttapi.Msg, error) {
+	var (
+		cfg []byte
+		err error
+	)
+
+	if err = validateEntity(e); err != nil {
+		return nil, fmt.Errorf("entity config is invalid: %w", err)
+	}
+
+	configTopic := discoveryTopic(e.EntityDetails)
+
+	if cfg, err = marshalConfig(e, e.EntityDetails); err != nil {
+		return nil, fmt.Errorf("marshal config: %w", err)
+	}
+
+	return mqttapi.NewMsg(configTopic, cfg), nil
+}
+
+func (e *DeviceTrackerEntity) validate() error {
+	return validateNoSensorStateOptions(e.EntityState, DeviceTracker)
+}
+
+func NewDeviceTrackerEntity() *DeviceTrackerEntity {
+	return &DeviceTrackerEntity{}
+}
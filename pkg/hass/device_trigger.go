@@ -0,0 +1,113 @@
+// Copyright (c) 2024 Joshua Rich <joshua.rich@gmail.com>
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package hass
+
+import (
+	"fmt"
+
+	mqttapi "github.com/joshuar/go-hass-anything/v12/pkg/mqtt"
+)
+
+// DeviceTrigger represents a device trigger, which shows up in the Home
+// Assistant automation editor under the device it is attached to. Unlike
+// entities, device triggers have no state; they are fired by publishing their
+// payload to their topic. For more details see
+// https://www.home-assistant.io/integrations/device_trigger.mqtt/
+type DeviceTrigger struct {
+	details        *EntityDetails
+	Origin         *Origin `json:"origin,omitempty"`
+	Device         *Device `json:"device" validate:"required"`
+	AutomationType string  `json:"automation_type"`
+	Topic          string  `json:"topic" validate:"required"`
+	Type           string  `json:"type" validate:"required"`
+	Subtype        string  `json:"subtype" validate:"required"`
+	Payload        string  `json:"payload,omitempty"`
+	ValueTemplate  string  `json:"value_template,omitempty"`
+}
+
+// WithDetails configures the app, ID and device of the trigger. The device is
+// required. Any other details, such as a name, are ignored as they are not
+// used by device triggers.
+func (t *DeviceTrigger) WithDetails(options ...DetailsOption) *DeviceTrigger {
+	t.details = WithDetails(DeviceAutomation, options...)
+	t.Topic = generateTopic("action", t.details)
+
+	return t
+}
+
+// WithTrigger sets the type and subtype of the trigger, which are shown in the
+// automation editor. The type is usually one of button_short_press,
+// button_short_release, button_long_press, button_long_release,
+// button_double_press, button_triple_press, button_quadruple_press or
+// button_quintuple_press, while the subtype is usually one of turn_on,
+// turn_off or button_1 to button_6. Other values can be used and will be shown
+// as-is.
+func (t *DeviceTrigger) WithTrigger(triggerType, subtype string) *DeviceTrigger {
+	t.Type = triggerType
+	t.Subtype = subtype
+
+	return t
+}
+
+// WithPayload sets the payload that fires the trigger. If not set, any payload
+// published to the topic of the trigger will fire it.
+func (t *DeviceTrigger) WithPayload(payload string) *DeviceTrigger {
+	t.Payload = payload
+
+	return t
+}
+
+// WithValueTemplate sets a template used to extract the value compared against
+// the payload.
+func (t *DeviceTrigger) WithValueTemplate(template string) *DeviceTrigger {
+	t.ValueTemplate = template
+
+	return t
+}
+
+// Fire will generate an *mqtt.Msg that can be used to fire the trigger.
+func (t *DeviceTrigger) Fire() *mqttapi.Msg {
+	return mqttapi.NewMsg(t.Topic, []byte(t.Payload))
+}
+
+// MarshalConfig will generate an *mqtt.Msg that can be used to configure the
+// trigger in Home Assistant.
+func (t *DeviceTrigger) MarshalConfig() (*mqttapi.Msg, error) {
+	var (
+		cfg []byte
+		err error
+	)
+
+	if t.details == nil {
+		return nil, fmt.Errorf("trigger config is invalid: %w", ErrNoDevice)
+	}
+
+	t.Origin = t.details.Origin
+	t.Device = t.details.Device
+
+	if err = validateEntity(t); err != nil {
+		return nil, fmt.Errorf("trigger config is invalid: %w", err)
+	}
+
+	configTopic := discoveryTopic(t.details)
+
+	if cfg, err = marshalConfig(t, t.details); err != nil {
+		return nil, fmt.Errorf("marshal config: %w", err)
+	}
+
+	return mqttapi.NewMsg(configTopic, cfg), nil
+}
+
+// entityDetails returns the details of the trigger.
+func (t *DeviceTrigger) entityDetails() *EntityDetails {
+	return t.details
+}
+
+func NewDeviceTrigger() *DeviceTrigger {
+	return &DeviceTrigger{
+		AutomationType: "trigger",
+	}
+}
@@ -0,0 +1,209 @@
+// Copyright (c) 2024 Joshua Rich <joshua.rich@gmail.com>
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package hass
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	mqttapi "github.com/joshuar/go-hass-anything/v12/pkg/mqtt"
+)
+
+const (
+	// DiscoveryPerEntity publishes a separate config message for each entity
+	// of a device.
+	DiscoveryPerEntity DiscoveryMode = iota
+	// DiscoveryPerDevice publishes a single config message for a device, that
+	// contains the config of all its entities.
+	DiscoveryPerDevice
+)
+
+// DiscoveryMode controls how the entities of a device are discovered by Home
+// Assistant.
+type DiscoveryMode int
+
+// DeviceDiscovery collects the entities of a device so that they can be
+// discovered by Home Assistant, either with a config message per entity or a
+// single config message for the whole device. For more details on device-based
+// discovery, see
+// https://www.home-assistant.io/integrations/mqtt/#device-discovery-payload
+type DeviceDiscovery struct {
+	device     *Device
+	origin     *Origin
+	id         string
+	entities   []Entity
+	mode       DiscoveryMode
+	abbreviate bool
+}
+
+// WithMode sets whether the entities of the device are discovered with a config
+// message per entity or a single config message for the device. Defaults to
+// DiscoveryPerEntity.
+func (d *DeviceDiscovery) WithMode(mode DiscoveryMode) *DeviceDiscovery {
+	d.mode = mode
+
+	return d
+}
+
+// WithAbbreviatedConfig ensures the device config, including the config of
+// each component, is marshaled using the abbreviated keys supported by Home
+// Assistant, when using DiscoveryPerDevice. To also shorten the topics of a
+// component with a base topic, use the AbbreviatedConfig option for the entity
+// details.
+func (d *DeviceDiscovery) WithAbbreviatedConfig() *DeviceDiscovery {
+	d.abbreviate = true
+
+	return d
+}
+
+// WithOrigin sets the origin of the device. Defaults to "Go Hass Anything".
+func (d *DeviceDiscovery) WithOrigin(origin *Origin) *DeviceDiscovery {
+	d.origin = origin
+
+	return d
+}
+
+// WithEntities adds the given entities (and/or device triggers) to the device.
+// Any entities without device info will be assigned the device.
+func (d *DeviceDiscovery) WithEntities(entities ...Entity) *DeviceDiscovery {
+	d.entities = append(d.entities, entities...)
+
+	return d
+}
+
+// MarshalConfig will generate the *mqtt.Msg(s) that can be used to configure
+// the device and its entities in Home Assistant. Depending on the discovery
+// mode, this will be a message for each entity or a single message for the
+// device. Entities that fail to marshal are returned as errors.
+func (d *DeviceDiscovery) MarshalConfig() ([]*mqttapi.Msg, error) {
+	var errs error
+
+	for _, entity := range d.entities {
+		if details := entity.entityDetails(); details != nil && details.Device == nil {
+			details.Device = d.device
+		}
+	}
+
+	if d.mode == DiscoveryPerDevice {
+		msg, err := d.marshalDeviceConfig()
+		if err != nil {
+			return nil, err
+		}
+
+		return []*mqttapi.Msg{msg}, nil
+	}
+
+	msgs := make([]*mqttapi.Msg, 0, len(d.entities))
+
+	for _, entity := range d.entities {
+		msg, err := entity.MarshalConfig()
+		if err != nil {
+			errs = errors.Join(errs, err)
+
+			continue
+		}
+
+		msgs = append(msgs, msg)
+	}
+
+	return msgs, errs
+}
+
+// marshalDeviceConfig generates a single config message for the device, with
+// the config of each entity, minus the device and origin info, added as a
+// component.
+func (d *DeviceDiscovery) marshalDeviceConfig() (*mqttapi.Msg, error) {
+	var errs error
+
+	components := make(map[string]map[string]json.RawMessage, len(d.entities))
+
+	for _, entity := range d.entities {
+		component, err := marshalComponent(entity)
+		if err != nil {
+			errs = errors.Join(errs, err)
+
+			continue
+		}
+
+		components[entity.entityDetails().UniqueID] = component
+	}
+
+	if errs != nil {
+		return nil, fmt.Errorf("device config is invalid: %w", errs)
+	}
+
+	origin := d.origin
+	if origin == nil {
+		origin = DefaultOriginInfo()(&EntityDetails{}).Origin
+	}
+
+	cfg, err := json.Marshal(struct {
+		Device     *Device                               `json:"device"`
+		Origin     *Origin                               `json:"origin"`
+		Components map[string]map[string]json.RawMessage `json:"components"`
+	}{
+		Device:     d.device,
+		Origin:     origin,
+		Components: components,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("marshal config: %w", err)
+	}
+
+	if d.abbreviate {
+		if cfg, err = abbreviateConfig(cfg, ""); err != nil {
+			return nil, err
+		}
+	}
+
+	return mqttapi.NewMsg(strings.Join([]string{HomeAssistantTopic, "device", d.id, "config"}, "/"), cfg), nil
+}
+
+// marshalComponent generates the config of the given entity as a component of
+// a device config. The device and origin info is removed and the platform of
+// the entity is added, using its abbreviation if the config is abbreviated.
+func marshalComponent(entity Entity) (map[string]json.RawMessage, error) {
+	msg, err := entity.MarshalConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	var component map[string]json.RawMessage
+
+	if err := json.Unmarshal(msg.Message, &component); err != nil {
+		return nil, fmt.Errorf("unmarshal config: %w", err)
+	}
+
+	delete(component, "device")
+	delete(component, "origin")
+	delete(component, abbreviations["device"])
+	delete(component, abbreviations["origin"])
+
+	platform, err := json.Marshal(entity.entityDetails().entityType.String())
+	if err != nil {
+		return nil, fmt.Errorf("marshal config: %w", err)
+	}
+
+	if entity.entityDetails().abbreviate {
+		component[abbreviations["platform"]] = platform
+	} else {
+		component["platform"] = platform
+	}
+
+	return component, nil
+}
+
+// NewDeviceDiscovery creates a new DeviceDiscovery for the given device. The id
+// is used to generate the config topic of the device, when using
+// DiscoveryPerDevice.
+func NewDeviceDiscovery(id string, device *Device) *DeviceDiscovery {
+	return &DeviceDiscovery{
+		id:     strings.ToLower(strings.ReplaceAll(id, " ", "_")),
+		device: device,
+	}
+}
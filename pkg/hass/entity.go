@@ -10,6 +10,9 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
 	"strings"
 
 	"github.com/eclipse/paho.golang/paho"
@@ -35,6 +38,47 @@ const (
 	Camera // camera
 	// Any entity that can send images.
 	Image // image
+	// An entity that can be set to one of a fixed list of options.
+	Select // select
+	// An entity that controls a light.
+	Light // light
+	// An entity that controls a heating, ventilation or air conditioning
+	// device.
+	Climate // climate
+	// An entity that controls a cover, such as blinds or a garage door.
+	Cover // cover
+	// An entity that controls a fan.
+	Fan // fan
+	// An entity that controls a humidifier or dehumidifier.
+	Humidifier // humidifier
+	// An entity that controls a water heater.
+	WaterHeater // water_heater
+	// An entity that controls a lock.
+	Lock // lock
+	// An entity that controls a valve.
+	Valve // valve
+	// An entity that controls a siren.
+	Siren // siren
+	// An entity that controls an alarm control panel.
+	AlarmControlPanel // alarm_control_panel
+	// An entity that represents stateless events, such as a doorbell press.
+	Event // event
+	// An entity that tracks the location of a device.
+	DeviceTracker // device_tracker
+	// An entity that advertises and installs updates.
+	Update // update
+	// An entity that controls a robot vacuum.
+	Vacuum // vacuum
+	// An entity that controls a robot lawn mower.
+	LawnMower // lawn_mower
+	// An entity that activates a scene.
+	Scene // scene
+	// An entity that receives notifications.
+	Notify // notify
+	// A tag scanner, which reports scanned tag IDs.
+	Tag // tag
+	// A device trigger, which appears under a device in the automation editor.
+	DeviceAutomation // device_automation
 )
 
 // EntityType is an iota that represents the type of entity (i.e., Switch or Sensor).
@@ -43,24 +87,32 @@ type EntityType int
 var (
 	ErrNoStateCallback   = errors.New("no state callback function")
 	ErrNoCommandCallback = errors.New("no command callback function")
+	ErrNoStateTopic      = errors.New("no state topic")
+	ErrNoCommandTopic    = errors.New("no command topic")
+	ErrNoAttributesTopic = errors.New("no attributes topic")
+	ErrUnknownState      = errors.New("unknown state")
+	ErrUnknownPayload    = errors.New("unknown payload")
+	ErrInvalidCode       = errors.New("invalid code")
+	ErrUnknownEventType  = errors.New("unknown event type")
+	ErrInvalidTransition = errors.New("invalid state transition")
+	ErrNoDevice          = errors.New("no device")
+	ErrInvalidValue      = errors.New("invalid value")
+	ErrUnchanged         = errors.New("unchanged since last published")
+	ErrFiltered          = errors.New("dropped by filter")
 )
 
 // HomeAssistantTopic is the prefix applied to all entity topics by default.
 // Typically, this defaults to "homeassistant". It is exposed by this package
-// such that it can be overridden as necessary.
+// such that it can be overridden as necessary. The agent sets it to the
+// mqtt.topicprefix preference. See also TopicStrategy for placing the
+// non-discovery topics of an entity elsewhere.
 var HomeAssistantTopic = "homeassistant"
 
-type EntityAvailability struct {
-	AvailabilityTopic    string `json:"availability_topic,omitempty" validate:"required"`
-	AvailabilityTemplate string `json:"availability_template,omitempty"`
-	PayloadAvailable     string `json:"payload_available,omitempty"`
-	PayloadNotAvailable  string `json:"payload_not_available,omitempty"`
-}
-
 // EntityAttributes are the fields that can be used for entities that have
 // additional attributes.
 type EntityAttributes struct {
 	attributesCallback func(args ...any) (json.RawMessage, error)
+	changes            *changeCache
 	// AttributesTopic defines the MQTT topic subscribed to receive a JSON
 	// dictionary payload and then set as sensor attributes. Implies force_update
 	// of the current sensor state when a message is received on this topic.
@@ -76,6 +128,10 @@ func (e *EntityAttributes) MarshalAttributes(args ...any) (*mqttapi.Msg, error)
 		err   error
 	)
 
+	if e == nil {
+		return nil, fmt.Errorf("could not marshal attributes: %w", ErrNoAttributesTopic)
+	}
+
 	if e.attributesCallback == nil {
 		return nil, fmt.Errorf("could not marshal attributes: %w", ErrNoStateCallback)
 	}
@@ -84,6 +140,10 @@ func (e *EntityAttributes) MarshalAttributes(args ...any) (*mqttapi.Msg, error)
 		return nil, err
 	}
 
+	if e.changes != nil && !e.changes.changed(state) {
+		return nil, fmt.Errorf("could not marshal attributes: %w", ErrUnchanged)
+	}
+
 	return mqttapi.NewMsg(e.AttributesTopic, state), nil
 }
 
@@ -121,28 +181,46 @@ func AttributesCallback(c func(args ...any) (json.RawMessage, error)) AttributeO
 	}
 }
 
+const (
+	stateClassMeasurement     = "measurement"
+	stateClassTotal           = "total"
+	stateClassTotalIncreasing = "total_increasing"
+)
+
 // EntityState represents all the fields that can be used for an entity that has
 // a state.
 type EntityState struct {
 	stateCallback func(args ...any) (json.RawMessage, error)
+	changes       *changeCache
+	filters       *filterPipeline
 	// StateTopic is the MQTT topic subscribed to receive state updates. A “None” payload resets
 	// to an unknown state. An empty payload is ignored.
 	StateTopic         string `json:"state_topic" validate:"required"`
-	ValueTemplate      string `json:"value_template"`
+	ValueTemplate      string `json:"value_template,omitempty"`
 	UnitOfMeasurement  string `json:"unit_of_measurement,omitempty"`
 	StateClass         string `json:"state_class,omitempty"`
-	DeviceClass        string `json:"device_lass,omitempty"`
+	DeviceClass        string `json:"device_class,omitempty"`
 	SuggestedPrecision uint   `json:"suggested_display_precision,omitempty"`
 }
 
 // MarshalState will generate an *mqtt.Msg for a given entity, that can be used
 // to publish the entity's state to the MQTT bus.
 func (e *EntityState) MarshalState(args ...any) (*mqttapi.Msg, error) {
+	return e.marshalState(true, args...)
+}
+
+// marshalState generates the state message of the entity. If onlyOnChange is
+// false, the state is marshaled even if it has not changed.
+func (e *EntityState) marshalState(onlyOnChange bool, args ...any) (*mqttapi.Msg, error) {
 	var (
 		state json.RawMessage
 		err   error
 	)
 
+	if e == nil {
+		return nil, fmt.Errorf("could not marshal state: %w", ErrNoStateTopic)
+	}
+
 	if e.stateCallback == nil {
 		return nil, fmt.Errorf("could not marshal state: %w", ErrNoStateCallback)
 	}
@@ -151,6 +229,18 @@ func (e *EntityState) MarshalState(args ...any) (*mqttapi.Msg, error) {
 		return nil, err
 	}
 
+	if e.filters != nil {
+		var publish bool
+		if state, publish = e.filters.apply(state); !publish {
+			return nil, fmt.Errorf("could not marshal state: %w", ErrFiltered)
+		}
+	}
+	// The state is recorded even when it is marshaled regardless, so that the
+	// max age applies from when it was last marshaled.
+	if changed := e.changes == nil || e.changes.changed(state); !changed && onlyOnChange {
+		return nil, fmt.Errorf("could not marshal state: %w", ErrUnchanged)
+	}
+
 	return mqttapi.NewMsg(e.StateTopic, state), nil
 }
 
@@ -218,7 +308,7 @@ func ValueTemplate(t string) StateOption {
 // StateClassMeasurement configures the State Class for the entity to be "measurement".
 func StateClassMeasurement() StateOption {
 	return func(e *EntityState) *EntityState {
-		e.StateClass = "measurement"
+		e.StateClass = stateClassMeasurement
 
 		return e
 	}
@@ -227,7 +317,7 @@ func StateClassMeasurement() StateOption {
 // StateClassTotal configures the State Class for the entity to be "total".
 func StateClassTotal() StateOption {
 	return func(e *EntityState) *EntityState {
-		e.StateClass = "total"
+		e.StateClass = stateClassTotal
 
 		return e
 	}
@@ -236,14 +326,15 @@ func StateClassTotal() StateOption {
 // StateClassTotalIncreasing configures the State Class for the entity to be "total_increasing".
 func StateClassTotalIncreasing() StateOption {
 	return func(e *EntityState) *EntityState {
-		e.StateClass = "total_increasing"
+		e.StateClass = stateClassTotalIncreasing
 
 		return e
 	}
 }
 
 // DeviceClass configures the Device Class of the entity. Device classes are
-// specific to the type of entity.
+// specific to the type of entity. Where available, prefer the typed options,
+// such as SensorDeviceClass, which cannot be misspelled.
 func DeviceClass(class string) StateOption {
 	return func(e *EntityState) *EntityState {
 		e.DeviceClass = class
@@ -252,16 +343,65 @@ func DeviceClass(class string) StateOption {
 	}
 }
 
+// validateNoStateDeviceClass checks that the DeviceClass state option was not
+// used, for entities that instead set their device class with the given typed
+// option. The device class of the state would otherwise be silently dropped
+// from the config.
+func validateNoStateDeviceClass(state *EntityState, option string) error {
+	if state == nil || state.DeviceClass == "" {
+		return nil
+	}
+
+	return fmt.Errorf("device class %q must be set with %s rather than as a state option", state.DeviceClass, option)
+}
+
+// validateNoSensorStateOptions checks that the device class, units, state
+// class and suggested precision state options were not used, for entities
+// whose config in Home Assistant does not accept them.
+func validateNoSensorStateOptions(state *EntityState, entityType EntityType) error {
+	if state == nil {
+		return nil
+	}
+
+	if state.DeviceClass != "" || state.UnitOfMeasurement != "" || state.StateClass != "" || state.SuggestedPrecision != 0 {
+		return fmt.Errorf("device class, units, state class and suggested precision are not valid for a %s", entityType)
+	}
+
+	return nil
+}
+
 type EntityDetails struct {
-	Origin     *Origin `json:"origin,omitempty"`
-	Device     *Device `json:"device,omitempty"`
-	UniqueID   string  `json:"unique_id" validate:"required"`
-	Name       string  `json:"name" validate:"required"`
-	app        string
-	Category   string `json:"entity_category,omitempty"`
-	Icon       string `json:"icon,omitempty" validate:"omitempty,startswith=mdi:"`
-	entityType EntityType
-	Enabled    bool `json:"enabled_by_default"`
+	Origin   *Origin `json:"origin,omitempty"`
+	Device   *Device `json:"device,omitempty"`
+	UniqueID string  `json:"unique_id" validate:"required"`
+	Name     string  `json:"name" validate:"required"`
+	app      string
+	Category string `json:"entity_category,omitempty"`
+	Icon     string `json:"icon,omitempty" validate:"omitempty,startswith=mdi:"`
+	// EntityPicture is a URL of a picture to display for the entity, instead
+	// of its icon.
+	EntityPicture string `json:"entity_picture,omitempty" validate:"omitempty,url"`
+	// TranslationKey is used to look up the name of the entity (and its states)
+	// in the translations of the MQTT integration in Home Assistant.
+	TranslationKey string `json:"translation_key,omitempty"`
+	// QoS is the maximum QoS level used by Home Assistant for the topics of
+	// the entity.
+	QoS uint8 `json:"qos,omitempty" validate:"lte=2"`
+	// ObjectID and DefaultEntityID are used by Home Assistant to generate the
+	// entity ID. DefaultEntityID replaces ObjectID in newer versions of Home
+	// Assistant, both are set by the EntityID option.
+	ObjectID        string `json:"object_id,omitempty" validate:"omitempty,excludesall=."`
+	DefaultEntityID string `json:"default_entity_id,omitempty"`
+	topics          TopicStrategy
+	entityType      EntityType
+	Enabled         bool `json:"enabled_by_default"`
+	abbreviate      bool
+}
+
+// entityDetails returns the details of an entity. As all entities embed
+// EntityDetails, it can be used to access the details of any entity.
+func (e *EntityDetails) entityDetails() *EntityDetails {
+	return e
 }
 
 type DetailsOption func(*EntityDetails) *EntityDetails
@@ -314,6 +454,20 @@ func ID(id string) DetailsOption {
 	}
 }
 
+// EntityID sets the object ID that Home Assistant will use to generate the
+// entity ID, rather than generating one from the name of the entity. For
+// example, a sensor with the object ID "garage_door" will have the entity ID
+// "sensor.garage_door", unless it is already taken. It will format the value
+// to be appropriate for an entity ID.
+func EntityID(objectID string) DetailsOption {
+	return func(e *EntityDetails) *EntityDetails {
+		e.ObjectID = strings.ToLower(strings.ReplaceAll(objectID, " ", "_"))
+		e.DefaultEntityID = e.entityType.String() + "." + e.ObjectID
+
+		return e
+	}
+}
+
 // Icon assigns the passed in icon string to the entity.
 func Icon(icon string) DetailsOption {
 	return func(e *EntityDetails) *EntityDetails {
@@ -323,6 +477,45 @@ func Icon(icon string) DetailsOption {
 	}
 }
 
+// EntityPicture assigns the passed in URL of a picture to the entity, which Home
+// Assistant will display instead of the icon of the entity.
+func EntityPicture(url string) DetailsOption {
+	return func(e *EntityDetails) *EntityDetails {
+		e.EntityPicture = url
+
+		return e
+	}
+}
+
+// TranslationKey assigns the passed in translation key to the entity.
+func TranslationKey(key string) DetailsOption {
+	return func(e *EntityDetails) *EntityDetails {
+		e.TranslationKey = key
+
+		return e
+	}
+}
+
+// QoS sets the maximum QoS level (0, 1 or 2) that Home Assistant will use for
+// the topics of the entity. Defaults to 0.
+func QoS(qos uint8) DetailsOption {
+	return func(e *EntityDetails) *EntityDetails {
+		e.QoS = qos
+
+		return e
+	}
+}
+
+// AsConfig ensures that the entity will appear as a configuration entity in
+// Home Assistant.
+func AsConfig() DetailsOption {
+	return func(e *EntityDetails) *EntityDetails {
+		e.Category = "config"
+
+		return e
+	}
+}
+
 // AsDiagnostic ensures that the entity will appear as a diagnostic entity in
 // Home Assistant.
 func AsDiagnostic() DetailsOption {
@@ -377,6 +570,8 @@ func DefaultOriginInfo() DetailsOption {
 type EntityCommand struct {
 	commandCallback func(p *paho.Publish)
 	CommandTopic    string `json:"command_topic" validate:"required"`
+	CommandTemplate string `json:"command_template,omitempty"`
+	Retain          bool   `json:"retain,omitempty"`
 }
 
 type CommandOption func(*EntityCommand) *EntityCommand
@@ -398,10 +593,39 @@ func CommandCallback(callback func(p *paho.Publish)) CommandOption {
 	}
 }
 
+// CommandTemplate configures the passed in template to be used to generate the
+// payload sent to the command topic by Home Assistant. Not all entities support
+// a command template.
+func CommandTemplate(t string) CommandOption {
+	return func(e *EntityCommand) *EntityCommand {
+		e.CommandTemplate = t
+
+		return e
+	}
+}
+
+// RetainCommands ensures that Home Assistant publishes commands as retained
+// messages, so that the last command is received on (re)subscribing. Home
+// Assistant applies it to all command topics of the entity, but it must be
+// passed to the primary command of the entity (i.e., to WithCommand) and has
+// no effect on any other commands. Entities without a primary command, such as
+// climate entities, have their own RetainCommands method instead.
+func RetainCommands() CommandOption {
+	return func(e *EntityCommand) *EntityCommand {
+		e.Retain = true
+
+		return e
+	}
+}
+
 // MarshallSubscription will generate an *mqtt.Subscription for a given entity,
 // which can be used to subscribe to an entity's command topic and execute a
 // callback on messages.
 func (e *EntityCommand) MarshalSubscription() (*mqttapi.Subscription, error) {
+	if e == nil {
+		return nil, fmt.Errorf("could not marshal subscription: %w", ErrNoCommandTopic)
+	}
+
 	if e.commandCallback == nil {
 		return nil, fmt.Errorf("could not marshal subscription: %w", ErrNoCommandCallback)
 	}
@@ -414,6 +638,337 @@ func (e *EntityCommand) MarshalSubscription() (*mqttapi.Subscription, error) {
 	return msg, nil
 }
 
+// EntityCommands is a collection of named commands, each with its own command
+// topic and callback. It is used by entities that accept more than one kind of
+// command, such as a climate entity, which has separate topics for setting the
+// mode, target temperature, fan mode and so on.
+type EntityCommands struct {
+	commands map[string]*EntityCommand
+}
+
+// addCommand creates a new command with the given name from the passed in
+// options. A command topic is generated for the command using its name.
+func (e *EntityCommands) addCommand(name string, details *EntityDetails, options ...CommandOption) *EntityCommand {
+	if e.commands == nil {
+		e.commands = make(map[string]*EntityCommand)
+	}
+
+	command := WithCommandOptions(options...)
+	command.CommandTopic = generateTopic(name+"/set", details)
+	e.commands[name] = command
+
+	return command
+}
+
+// MarshalSubscriptions will generate an *mqtt.Subscription for each of the
+// named commands of an entity, each of which will execute the callback for that
+// command on messages. Any commands without a callback are returned as errors.
+func (e *EntityCommands) MarshalSubscriptions() ([]*mqttapi.Subscription, error) {
+	var errs error
+
+	subscriptions := make([]*mqttapi.Subscription, 0, len(e.commands))
+
+	for _, name := range slices.Sorted(maps.Keys(e.commands)) {
+		subscription, err := e.commands[name].MarshalSubscription()
+		if err != nil {
+			errs = errors.Join(errs, fmt.Errorf("%s: %w", name, err))
+
+			continue
+		}
+
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	return subscriptions, errs
+}
+
+// EntityStates is a collection of named states, each with its own state topic
+// and callback. It is used by entities that report more than one kind of
+// state, such as a climate entity, which has separate topics for the current
+// mode, current temperature and so on.
+type EntityStates struct {
+	states map[string]*EntityState
+}
+
+// addState creates a new state with the given name from the passed in options.
+// A state topic is generated for the state using its name.
+func (e *EntityStates) addState(name string, details *EntityDetails, options ...StateOption) *EntityState {
+	if e.states == nil {
+		e.states = make(map[string]*EntityState)
+	}
+
+	state := WithStateOptions(options...)
+	state.StateTopic = generateTopic(name+"/state", details)
+	e.states[name] = state
+
+	return state
+}
+
+// MarshalStates will generate an *mqtt.Msg for each of the named states of an
+// entity that has a state callback. States without a callback are skipped, as
+// it is assumed their state is published by other means.
+func (e *EntityStates) MarshalStates(args ...any) ([]*mqttapi.Msg, error) {
+	var errs error
+
+	msgs := make([]*mqttapi.Msg, 0, len(e.states))
+
+	for _, name := range slices.Sorted(maps.Keys(e.states)) {
+		if e.states[name].stateCallback == nil {
+			continue
+		}
+
+		msg, err := e.states[name].MarshalState(args...)
+		if notPublished(err) {
+			continue
+		}
+
+		if err != nil {
+			errs = errors.Join(errs, fmt.Errorf("%s: %w", name, err))
+
+			continue
+		}
+
+		msgs = append(msgs, msg)
+	}
+
+	return msgs, errs
+}
+
+// MarshalNamedState will generate an *mqtt.Msg for the state with the given
+// name, that can be used to publish just that state to the MQTT bus.
+func (e *EntityStates) MarshalNamedState(name string, args ...any) (*mqttapi.Msg, error) {
+	state, found := e.states[name]
+	if !found {
+		return nil, fmt.Errorf("could not marshal state %s: %w", name, ErrUnknownState)
+	}
+
+	return state.MarshalState(args...)
+}
+
+// marshalSubscriptions will generate an *mqtt.Subscription for the given
+// command (if any) followed by each of the given named commands. It is used by
+// entities that have both a primary command topic and additional named command
+// topics.
+func marshalSubscriptions(command *EntityCommand, commands *EntityCommands) ([]*mqttapi.Subscription, error) {
+	subscriptions, errs := commands.MarshalSubscriptions()
+
+	if command != nil {
+		subscription, err := command.MarshalSubscription()
+		if err != nil {
+			errs = errors.Join(errs, err)
+		} else {
+			subscriptions = append([]*mqttapi.Subscription{subscription}, subscriptions...)
+		}
+	}
+
+	return subscriptions, errs
+}
+
+// marshalStates will generate an *mqtt.Msg for the given state (if any and it
+// has a callback) followed by each of the given named states. It is used by
+// entities that have both a primary state topic and additional named state
+// topics.
+func marshalStates(state *EntityState, states *EntityStates, args ...any) ([]*mqttapi.Msg, error) {
+	msgs, errs := states.MarshalStates(args...)
+
+	if state != nil && state.stateCallback != nil {
+		msg, err := state.MarshalState(args...)
+
+		switch {
+		case notPublished(err):
+		case err != nil:
+			errs = errors.Join(errs, err)
+		default:
+			msgs = append([]*mqttapi.Msg{msg}, msgs...)
+		}
+	}
+
+	return msgs, errs
+}
+
+// notPublished returns whether the error from marshaling a state is because
+// the state was deliberately not published, either as it has not changed or
+// it was dropped by a filter.
+func notPublished(err error) bool {
+	return errors.Is(err, ErrUnchanged) || errors.Is(err, ErrFiltered)
+}
+
+// EntityModes represents the fields used by entities that have a list of modes,
+// where the current mode can be set through a mode command topic and is
+// reported on a mode state topic.
+type EntityModes struct {
+	Modes               []string `json:"modes,omitempty"`
+	ModeCommandTopic    string   `json:"mode_command_topic,omitempty"`
+	ModeCommandTemplate string   `json:"mode_command_template,omitempty"`
+	ModeStateTopic      string   `json:"mode_state_topic,omitempty"`
+	ModeStateTemplate   string   `json:"mode_state_template,omitempty"`
+}
+
+// newEntityModes creates the modes of an entity from the given options. Any
+// mode command or state is added to the commands and states of the entity.
+//
+//nolint:lll
+func newEntityModes(details *EntityDetails, commands *EntityCommands, states *EntityStates, options ...ModeOption) *EntityModes {
+	modes, command, state := withModes("mode", details, commands, states, options...)
+	entityModes := &EntityModes{Modes: modes}
+
+	if command != nil {
+		entityModes.ModeCommandTopic = command.CommandTopic
+		entityModes.ModeCommandTemplate = command.CommandTemplate
+	}
+
+	if state != nil {
+		entityModes.ModeStateTopic = state.StateTopic
+		entityModes.ModeStateTemplate = state.ValueTemplate
+	}
+
+	return entityModes
+}
+
+// EntityPresetModes represents the fields used by entities that have a list of
+// preset modes, where the current preset mode can be set through a preset mode
+// command topic and is reported on a preset mode state topic.
+type EntityPresetModes struct {
+	PresetModes               []string `json:"preset_modes,omitempty"`
+	PresetModeCommandTopic    string   `json:"preset_mode_command_topic,omitempty"`
+	PresetModeCommandTemplate string   `json:"preset_mode_command_template,omitempty"`
+	PresetModeStateTopic      string   `json:"preset_mode_state_topic,omitempty"`
+	PresetModeValueTemplate   string   `json:"preset_mode_value_template,omitempty"`
+}
+
+// newEntityPresetModes creates the preset modes of an entity from the given
+// options. Any preset mode command or state is added to the commands and states
+// of the entity.
+//
+//nolint:lll
+func newEntityPresetModes(details *EntityDetails, commands *EntityCommands, states *EntityStates, options ...ModeOption) *EntityPresetModes {
+	modes, command, state := withModes("preset_mode", details, commands, states, options...)
+	entityModes := &EntityPresetModes{PresetModes: modes}
+
+	if command != nil {
+		entityModes.PresetModeCommandTopic = command.CommandTopic
+		entityModes.PresetModeCommandTemplate = command.CommandTemplate
+	}
+
+	if state != nil {
+		entityModes.PresetModeStateTopic = state.StateTopic
+		entityModes.PresetModeValueTemplate = state.ValueTemplate
+	}
+
+	return entityModes
+}
+
+type modeList struct {
+	modes          []string
+	commandOptions []CommandOption
+	stateOptions   []StateOption
+	hasCommand     bool
+	hasState       bool
+}
+
+// ModeOption is used to configure a list of modes of an entity, along with the
+// command used to set the current mode and the state used to report it.
+type ModeOption func(*modeList) *modeList
+
+// ModeList sets the list of modes that are supported.
+func ModeList(modes ...string) ModeOption {
+	return func(m *modeList) *modeList {
+		m.modes = modes
+
+		return m
+	}
+}
+
+// ModeCommand configures a command topic through which the current mode can be
+// set, with the given command options.
+func ModeCommand(options ...CommandOption) ModeOption {
+	return func(m *modeList) *modeList {
+		m.hasCommand = true
+		m.commandOptions = options
+
+		return m
+	}
+}
+
+// ModeState configures a state topic on which the current mode is reported,
+// with the given state options.
+func ModeState(options ...StateOption) ModeOption {
+	return func(m *modeList) *modeList {
+		m.hasState = true
+		m.stateOptions = options
+
+		return m
+	}
+}
+
+// withModes applies the given mode options, adding a named command and state
+// to the entity if requested. It returns the list of modes and the command and
+// state that were added, which may be nil.
+//
+//nolint:lll
+func withModes(name string, details *EntityDetails, commands *EntityCommands, states *EntityStates, options ...ModeOption) ([]string, *EntityCommand, *EntityState) {
+	var (
+		list    = &modeList{}
+		command *EntityCommand
+		state   *EntityState
+	)
+
+	for _, option := range options {
+		list = option(list)
+	}
+
+	if list.hasCommand {
+		command = commands.addCommand(name, details, list.commandOptions...)
+	}
+
+	if list.hasState {
+		state = states.addState(name, details, list.stateOptions...)
+	}
+
+	return list.modes, command, state
+}
+
+// decodeCommand wraps a handler that accepts a typed command value as a
+// callback for raw MQTT messages. The payload of each message is converted with
+// the given decoder and, if successful, passed to the handler. Payloads that
+// cannot be decoded are logged and dropped.
+func decodeCommand[T any](decoder func(payload []byte) (T, error), handler func(T)) func(p *paho.Publish) {
+	return func(p *paho.Publish) {
+		value, err := decoder(p.Payload)
+		if err != nil {
+			slog.Warn("Could not decode command payload.",
+				slog.String("topic", p.Topic),
+				slog.Any("error", err))
+
+			return
+		}
+
+		handler(value)
+	}
+}
+
+// decodeJSON is a decoder for use with decodeCommand that unmarshals a JSON
+// payload into a value of type T.
+func decodeJSON[T any](payload []byte) (*T, error) {
+	value := new(T)
+
+	if err := json.Unmarshal(payload, value); err != nil {
+		return nil, fmt.Errorf("could not unmarshal payload: %w", err)
+	}
+
+	return value, nil
+}
+
+// payloadOrDefault returns the given payload, or the default payload if it is
+// not set.
+func payloadOrDefault(payload, defaultPayload string) string {
+	if payload == "" {
+		return defaultPayload
+	}
+
+	return payload
+}
+
 type EntityEncoding struct {
 	Encoding      string `json:"encoding,omitempty"`
 	ImageEncoding string `json:"image_encoding,omitempty"`
@@ -456,34 +1011,3 @@ func WithBase64ImageEncoding() EncodingOption {
 		return e
 	}
 }
-
-// Device contains information about the device an entity is a part of to tie it
-// into the device registry in Home Assistant.
-type Device struct {
-	Name          string   `json:"name"`
-	Manufacturer  string   `json:"manufacturer,omitempty"`
-	Model         string   `json:"model,omitempty"`
-	HWVersion     string   `json:"hw_version,omitempty"`
-	SWVersion     string   `json:"sw_version,omitempty"`
-	URL           string   `json:"configuration_url,omitempty"`
-	SuggestedArea string   `json:"suggested_area,omitempty"`
-	Identifiers   []string `json:"identifiers"`
-	Connections   []string `json:"connections,omitempty"`
-}
-
-// Origin contains information about the app that is responsible for the entity.
-// It is used by Home Assistant for logging and display purposes.
-type Origin struct {
-	Name    string `json:"name"`
-	Version string `json:"sw_version,omitempty"`
-	URL     string `json:"support_url,omitempty"`
-}
-
-// GenerateTopic takes the given topicName and entityID and generates an
-// appropriate topic based on the EntityType as per the Home Assistant topic
-// naming recommendations.
-func generateTopic(topicName string, details *EntityDetails) string {
-	appID := strings.ToLower(strings.ReplaceAll(details.app, " ", "_"))
-
-	return strings.Join([]string{HomeAssistantTopic, details.entityType.String(), appID, details.UniqueID, topicName}, "/")
-}
@@ -17,11 +17,31 @@ func _() {
 	_ = x[Text-6]
 	_ = x[Camera-7]
 	_ = x[Image-8]
+	_ = x[Select-9]
+	_ = x[Light-10]
+	_ = x[Climate-11]
+	_ = x[Cover-12]
+	_ = x[Fan-13]
+	_ = x[Humidifier-14]
+	_ = x[WaterHeater-15]
+	_ = x[Lock-16]
+	_ = x[Valve-17]
+	_ = x[Siren-18]
+	_ = x[AlarmControlPanel-19]
+	_ = x[Event-20]
+	_ = x[DeviceTracker-21]
+	_ = x[Update-22]
+	_ = x[Vacuum-23]
+	_ = x[LawnMower-24]
+	_ = x[Scene-25]
+	_ = x[Notify-26]
+	_ = x[Tag-27]
+	_ = x[DeviceAutomation-28]
 }
 
-const _EntityType_name = "unknownsensorbinary_sensorbuttonnumberswitchtextcameraimage"
+const _EntityType_name = "unknownsensorbinary_sensorbuttonnumberswitchtextcameraimageselectlightclimatecoverfanhumidifierwater_heaterlockvalvesirenalarm_control_paneleventdevice_trackerupdatevacuumlawn_mowerscenenotifytagdevice_automation"
 
-var _EntityType_index = [...]uint8{0, 7, 13, 26, 32, 38, 44, 48, 54, 59}
+var _EntityType_index = [...]uint8{0, 7, 13, 26, 32, 38, 44, 48, 54, 59, 65, 70, 77, 82, 85, 95, 107, 111, 116, 121, 140, 145, 159, 165, 171, 181, 186, 192, 195, 212}
 
 func (i EntityType) String() string {
 	if i < 0 || i >= EntityType(len(_EntityType_index)-1) {
@@ -0,0 +1,208 @@
+// Copyright (c) 2024 Joshua Rich <joshua.rich@gmail.com>
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package hass
+
+import (
+	"errors"
+	"fmt"
+
+	mqttapi "github.com/joshuar/go-hass-anything/v12/pkg/mqtt"
+)
+
+// Entity represents any of the entities (or device triggers) provided by this
+// package.
+type Entity interface {
+	// MarshalConfig will generate an *mqtt.Msg that can be used to configure
+	// the entity in Home Assistant.
+	MarshalConfig() (*mqttapi.Msg, error)
+	entityDetails() *EntityDetails
+}
+
+// Entities may optionally satisfy one or more of the following interfaces,
+// which are used by EntitySet to generate their states and subscriptions.
+type (
+	stateMarshaler interface {
+		MarshalState(args ...any) (*mqttapi.Msg, error)
+	}
+	statesMarshaler interface {
+		MarshalStates(args ...any) ([]*mqttapi.Msg, error)
+	}
+	attributesMarshaler interface {
+		MarshalAttributes(args ...any) (*mqttapi.Msg, error)
+	}
+	subscriptionMarshaler interface {
+		MarshalSubscription() (*mqttapi.Subscription, error)
+	}
+	subscriptionsMarshaler interface {
+		MarshalSubscriptions() ([]*mqttapi.Subscription, error)
+	}
+)
+
+// EntitySet is a collection of entities. An app can add its entities to an
+// EntitySet once and then use it to generate the config, state and
+// subscription messages of all of its entities.
+type EntitySet struct {
+	entities []Entity
+}
+
+// Add adds the given entities to the set.
+func (s *EntitySet) Add(entities ...Entity) *EntitySet {
+	s.entities = append(s.entities, entities...)
+
+	return s
+}
+
+// Configuration will generate an *mqtt.Msg for the config of each entity in
+// the set. Any entities that fail to marshal are returned as errors.
+func (s *EntitySet) Configuration() ([]*mqttapi.Msg, error) {
+	var errs error
+
+	msgs := make([]*mqttapi.Msg, 0, len(s.entities))
+
+	for _, entity := range s.entities {
+		msg, err := entity.MarshalConfig()
+		if err != nil {
+			errs = errors.Join(errs, fmt.Errorf("%s: %w", entityID(entity), err))
+
+			continue
+		}
+
+		msgs = append(msgs, msg)
+	}
+
+	return msgs, errs
+}
+
+// States will generate an *mqtt.Msg for the state(s) and attributes of each
+// entity in the set that has a state or attributes callback. Entities without
+// a callback are skipped, as it is assumed their state is published by other
+// means. States and attributes that are unchanged (if only published on
+// change) or dropped by a filter are skipped. Any states that fail to marshal are returned as errors.
+func (s *EntitySet) States() ([]*mqttapi.Msg, error) {
+	var errs error
+
+	msgs := make([]*mqttapi.Msg, 0, len(s.entities))
+
+	for _, entity := range s.entities {
+		var (
+			states []*mqttapi.Msg
+			err    error
+		)
+
+		switch entity := entity.(type) {
+		case statesMarshaler:
+			states, err = entity.MarshalStates()
+		case stateMarshaler:
+			var state *mqttapi.Msg
+			if state, err = entity.MarshalState(); state != nil {
+				states = append(states, state)
+			}
+		}
+
+		if entity, ok := entity.(attributesMarshaler); ok {
+			attributes, attributesErr := entity.MarshalAttributes()
+			if attributes != nil {
+				states = append(states, attributes)
+			}
+
+			err = errors.Join(err, attributesErr)
+		}
+
+		msgs = append(msgs, states...)
+
+		if err = skipUnconfigured(err, ErrNoStateTopic, ErrNoAttributesTopic, ErrNoStateCallback, ErrUnchanged, ErrFiltered); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("%s: %w", entityID(entity), err))
+		}
+	}
+
+	return msgs, errs
+}
+
+// Subscriptions will generate an *mqtt.Subscription for each command topic of
+// each entity in the set. Entities without commands are skipped. Any commands
+// without a callback are returned as errors.
+func (s *EntitySet) Subscriptions() ([]*mqttapi.Subscription, error) {
+	var errs error
+
+	subscriptions := make([]*mqttapi.Subscription, 0, len(s.entities))
+
+	for _, entity := range s.entities {
+		var err error
+
+		switch entity := entity.(type) {
+		case subscriptionsMarshaler:
+			var subs []*mqttapi.Subscription
+
+			subs, err = entity.MarshalSubscriptions()
+			subscriptions = append(subscriptions, subs...)
+		case subscriptionMarshaler:
+			var sub *mqttapi.Subscription
+			if sub, err = entity.MarshalSubscription(); sub != nil {
+				subscriptions = append(subscriptions, sub)
+			}
+		}
+
+		if err = skipUnconfigured(err, ErrNoCommandTopic); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("%s: %w", entityID(entity), err))
+		}
+	}
+
+	return subscriptions, errs
+}
+
+// NewEntitySet creates a new EntitySet containing the given entities.
+func NewEntitySet(entities ...Entity) *EntitySet {
+	return &EntitySet{
+		entities: entities,
+	}
+}
+
+// skipUnconfigured filters the given (possibly joined) error, removing any
+// errors that match one of the given targets. These represent states, topics or
+// callbacks that the entity does not have, rather than actual failures.
+func skipUnconfigured(err error, targets ...error) error {
+	if err == nil {
+		return nil
+	}
+
+	var errs []error
+
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		errs = joined.Unwrap()
+	} else {
+		errs = []error{err}
+	}
+
+	var remaining error
+
+	for _, err := range errs {
+		if !isAny(err, targets...) {
+			remaining = errors.Join(remaining, err)
+		}
+	}
+
+	return remaining
+}
+
+// isAny returns whether the given error matches any of the given targets.
+func isAny(err error, targets ...error) bool {
+	for _, target := range targets {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// entityID returns an identifier for the given entity, for use in errors.
+func entityID(entity Entity) string {
+	if details := entity.entityDetails(); details != nil && details.UniqueID != "" {
+		return details.UniqueID
+	}
+
+	return fmt.Sprintf("%T", entity)
+}
@@ -0,0 +1,143 @@
+// Copyright (c) 2024 Joshua Rich <joshua.rich@gmail.com>
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+//go:generate go run golang.org/x/tools/cmd/stringer -type=EventType -output event_entity_generated.go -linecomment
+package hass
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+
+	mqttapi "github.com/joshuar/go-hass-anything/v12/pkg/mqtt"
+)
+
+const (
+	EventTypeNone     EventType = iota //
+	EventTypeButton                    // button
+	EventTypeDoorbell                  // doorbell
+	EventTypeMotion                    // motion
+)
+
+// EventType is the type of event, which defines how it is displayed in Home
+// Assistant.
+type EventType int
+
+// EventEntity represents an entity that reports stateless events, such as a
+// doorbell press or a gesture. Events are fired on the state topic and must be
+// one of the declared event types. For more details see
+// https://www.home-assistant.io/integrations/event.mqtt/
+type EventEntity struct {
+	*EntityDetails
+	*EntityAvailability
+	*EntityState
+	*EntityAttributes
+	EventType  string   `json:"device_class,omitempty"`
+	EventTypes []string `json:"event_types" validate:"required,unique"`
+}
+
+// WithEventType sets the type of event, defining how it gets displayed in Home
+// Assistant. The DeviceClass state option cannot be used for events. See also:
+// https://www.home-assistant.io/integrations/event/#device-class
+func (e *EventEntity) WithEventType(eventType EventType) *EventEntity {
+	e.EventType = eventType.String()
+
+	return e
+}
+
+// WithEventTypes sets the list of event types the entity can fire. It is
+// required.
+func (e *EventEntity) WithEventTypes(eventTypes ...string) *EventEntity {
+	e.EventTypes = eventTypes
+
+	return e
+}
+
+func (e *EventEntity) WithDetails(options ...DetailsOption) *EventEntity {
+	e.EntityDetails = WithDetails(Event, options...)
+
+	return e
+}
+
+// WithState configures the state topic the events are fired on. It is
+// required. A value template can be provided if the event payloads need to be
+// transformed into the format expected by Home Assistant.
+func (e *EventEntity) WithState(options ...StateOption) *EventEntity {
+	e.EntityState = WithStateOptions(options...)
+	e.StateTopic = generateTopic("state", e.EntityDetails)
+
+	return e
+}
+
+func (e *EventEntity) WithAttributes(options ...AttributeOption) *EventEntity {
+	e.EntityAttributes = WithAttributesOptions(options...)
+	e.AttributesTopic = generateTopic("attributes", e.EntityDetails)
+
+	return e
+}
+
+// WithAvailability configures the topics on which the availability of the
+// entity is reported.
+func (e *EventEntity) WithAvailability(options ...AvailabilityOption) *EventEntity {
+	e.EntityAvailability = WithAvailabilityOptions(options...)
+
+	return e
+}
+
+// Fire will generate an *mqtt.Msg for an event of the given type, with the
+// given (optional) attributes, that can be used to fire the event. The event
+// type must be one of the declared event types of the entity.
+func (e *EventEntity) Fire(eventType string, attrs map[string]any) (*mqttapi.Msg, error) {
+	if e.EntityState == nil {
+		return nil, fmt.Errorf("could not fire event: %w", ErrNoStateTopic)
+	}
+
+	if !slices.Contains(e.EventTypes, eventType) {
+		return nil, fmt.Errorf("could not fire event: %w: %s", ErrUnknownEventType, eventType)
+	}
+
+	event := make(map[string]any, len(attrs)+1)
+	maps.Copy(event, attrs)
+	event["event_type"] = eventType
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, fmt.Errorf("could not marshal event: %w", err)
+	}
+
+	return mqttapi.NewMsg(e.StateTopic, payload), nil
+}
+
+func (e *EventEntity) MarshalConfig() (*mqttapi.Msg, error) {
+	var (
+		cfg []byte
+		err error
+	)
+
+	if e.EntityState == nil {
+		return nil, fmt.Errorf("entity config is invalid: %w", ErrNoStateTopic)
+	}
+
+	if err = validateEntity(e); err != nil {
+		return nil, fmt.Errorf("entity config is invalid: %w", err)
+	}
+
+	configTopic := discoveryTopic(e.EntityDetails)
+
+	if cfg, err = marshalConfig(e, e.EntityDetails); err != nil {
+		return nil, fmt.Errorf("marshal config: %w", err)
+	}
+
+	return mqttapi.NewMsg(configTopic, cfg), nil
+}
+
+func (e *EventEntity) validate() error {
+	return validateNoStateDeviceClass(e.EntityState, "WithEventType")
+}
+
+func NewEventEntity() *EventEntity {
+	return &EventEntity{}
+}
@@ -0,0 +1,252 @@
+// Copyright (c) 2024 Joshua Rich <joshua.rich@gmail.com>
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package hass
+
+import (
+	"fmt"
+
+	mqttapi "github.com/joshuar/go-hass-anything/v12/pkg/mqtt"
+)
+
+// FanEntity represents an entity that controls a fan. Fans are turned on and
+// off through a command topic and can optionally have their speed (as a
+// percentage), preset mode, oscillation and direction controlled through their
+// own command topics. For more details see
+// https://www.home-assistant.io/integrations/fan.mqtt/
+//
+//nolint:lll
+type FanEntity struct {
+	*EntityDetails
+	*EntityAvailability
+	*EntityCommand
+	*EntityState
+	*EntityAttributes
+	*EntityPresetModes
+	EntityCommands             `json:"-"`
+	EntityStates               `json:"-"`
+	StateValueTemplate         string `json:"state_value_template,omitempty"`
+	PercentageCommandTopic     string `json:"percentage_command_topic,omitempty"`
+	PercentageCommandTemplate  string `json:"percentage_command_template,omitempty"`
+	PercentageStateTopic       string `json:"percentage_state_topic,omitempty"`
+	PercentageValueTemplate    string `json:"percentage_value_template,omitempty"`
+	OscillationCommandTopic    string `json:"oscillation_command_topic,omitempty"`
+	OscillationCommandTemplate string `json:"oscillation_command_template,omitempty"`
+	OscillationStateTopic      string `json:"oscillation_state_topic,omitempty"`
+	OscillationValueTemplate   string `json:"oscillation_value_template,omitempty"`
+	DirectionCommandTopic      string `json:"direction_command_topic,omitempty"`
+	DirectionCommandTemplate   string `json:"direction_command_template,omitempty"`
+	DirectionStateTopic        string `json:"direction_state_topic,omitempty"`
+	DirectionValueTemplate     string `json:"direction_value_template,omitempty"`
+	PayloadOn                  string `json:"payload_on,omitempty"`
+	PayloadOff                 string `json:"payload_off,omitempty"`
+	PayloadOscillationOn       string `json:"payload_oscillation_on,omitempty"`
+	PayloadOscillationOff      string `json:"payload_oscillation_off,omitempty"`
+	PayloadResetPercentage     string `json:"payload_reset_percentage,omitempty"`
+	PayloadResetPresetMode     string `json:"payload_reset_preset_mode,omitempty"`
+	SpeedRangeMin              int    `json:"speed_range_min,omitempty" validate:"omitempty,gt=0"`
+	SpeedRangeMax              int    `json:"speed_range_max,omitempty" validate:"omitempty,gtefield=SpeedRangeMin"`
+	Optimistic                 bool   `json:"optimistic,omitempty"`
+}
+
+// OptimisticMode ensures the fan works in optimistic mode.
+func (e *FanEntity) OptimisticMode() *FanEntity {
+	e.Optimistic = true
+
+	return e
+}
+
+// WithOnPayload sets the payload that represents the running state. Defaults
+// to ON.
+func (e *FanEntity) WithOnPayload(payload string) *FanEntity {
+	e.PayloadOn = payload
+
+	return e
+}
+
+// WithOffPayload sets the payload that represents the stopped state. Defaults
+// to OFF.
+func (e *FanEntity) WithOffPayload(payload string) *FanEntity {
+	e.PayloadOff = payload
+
+	return e
+}
+
+// WithOscillationPayloads sets the payloads that represent the oscillation on
+// and off states. Defaults to oscillate_on and oscillate_off.
+func (e *FanEntity) WithOscillationPayloads(on, off string) *FanEntity {
+	e.PayloadOscillationOn = on
+	e.PayloadOscillationOff = off
+
+	return e
+}
+
+// WithResetPayloads sets the payloads that, when received on the percentage
+// and preset mode state topics respectively, reset them to an unknown state.
+func (e *FanEntity) WithResetPayloads(percentage, presetMode string) *FanEntity {
+	e.PayloadResetPercentage = percentage
+	e.PayloadResetPresetMode = presetMode
+
+	return e
+}
+
+// WithSpeedRange sets the minimum and maximum speed of the fan, which Home
+// Assistant will translate to and from a percentage. The minimum must be
+// greater than 0. Defaults to 1 and 100 respectively.
+//
+//nolint:predeclared
+func (e *FanEntity) WithSpeedRange(min, max int) *FanEntity {
+	e.SpeedRangeMin = min
+	e.SpeedRangeMax = max
+
+	return e
+}
+
+func (e *FanEntity) WithDetails(options ...DetailsOption) *FanEntity {
+	e.EntityDetails = WithDetails(Fan, options...)
+
+	return e
+}
+
+// WithState configures the state used to report whether the fan is on or off.
+func (e *FanEntity) WithState(options ...StateOption) *FanEntity {
+	e.EntityState = WithStateOptions(options...)
+	e.StateTopic = generateTopic("state", e.EntityDetails)
+	// Fans use state_value_template rather than value_template.
+	e.StateValueTemplate = e.ValueTemplate
+	e.ValueTemplate = ""
+
+	return e
+}
+
+// WithCommand configures the command used to turn the fan on or off.
+func (e *FanEntity) WithCommand(options ...CommandOption) *FanEntity {
+	e.EntityCommand = WithCommandOptions(options...)
+	e.CommandTopic = generateTopic("set", e.EntityDetails)
+
+	return e
+}
+
+// WithPercentageCommand configures the command used to set the speed of the
+// fan, as a percentage.
+func (e *FanEntity) WithPercentageCommand(options ...CommandOption) *FanEntity {
+	command := e.addCommand("percentage", e.EntityDetails, options...)
+	e.PercentageCommandTopic = command.CommandTopic
+	e.PercentageCommandTemplate = command.CommandTemplate
+
+	return e
+}
+
+// WithPercentageState configures the state used to report the speed of the
+// fan, as a percentage.
+func (e *FanEntity) WithPercentageState(options ...StateOption) *FanEntity {
+	state := e.addState("percentage", e.EntityDetails, options...)
+	e.PercentageStateTopic = state.StateTopic
+	e.PercentageValueTemplate = state.ValueTemplate
+
+	return e
+}
+
+// WithPresetModes configures the preset modes supported by the fan, and the
+// command and state used to set and report the current preset mode.
+func (e *FanEntity) WithPresetModes(options ...ModeOption) *FanEntity {
+	e.EntityPresetModes = newEntityPresetModes(e.EntityDetails, &e.EntityCommands, &e.EntityStates, options...)
+
+	return e
+}
+
+// WithOscillationCommand configures the command used to turn oscillation of
+// the fan on or off.
+func (e *FanEntity) WithOscillationCommand(options ...CommandOption) *FanEntity {
+	command := e.addCommand("oscillation", e.EntityDetails, options...)
+	e.OscillationCommandTopic = command.CommandTopic
+	e.OscillationCommandTemplate = command.CommandTemplate
+
+	return e
+}
+
+// WithOscillationState configures the state used to report whether the fan is
+// oscillating.
+func (e *FanEntity) WithOscillationState(options ...StateOption) *FanEntity {
+	state := e.addState("oscillation", e.EntityDetails, options...)
+	e.OscillationStateTopic = state.StateTopic
+	e.OscillationValueTemplate = state.ValueTemplate
+
+	return e
+}
+
+// WithDirectionCommand configures the command used to set the direction of the
+// fan. Directions are either "forward" or "reverse".
+func (e *FanEntity) WithDirectionCommand(options ...CommandOption) *FanEntity {
+	command := e.addCommand("direction", e.EntityDetails, options...)
+	e.DirectionCommandTopic = command.CommandTopic
+	e.DirectionCommandTemplate = command.CommandTemplate
+
+	return e
+}
+
+// WithDirectionState configures the state used to report the direction of the
+// fan. Directions are either "forward" or "reverse".
+func (e *FanEntity) WithDirectionState(options ...StateOption) *FanEntity {
+	state := e.addState("direction", e.EntityDetails, options...)
+	e.DirectionStateTopic = state.StateTopic
+	e.DirectionValueTemplate = state.ValueTemplate
+
+	return e
+}
+
+func (e *FanEntity) WithAttributes(options ...AttributeOption) *FanEntity {
+	e.EntityAttributes = WithAttributesOptions(options...)
+	e.AttributesTopic = generateTopic("attributes", e.EntityDetails)
+
+	return e
+}
+
+// WithAvailability configures the topics on which the availability of the
+// entity is reported.
+func (e *FanEntity) WithAvailability(options ...AvailabilityOption) *FanEntity {
+	e.EntityAvailability = WithAvailabilityOptions(options...)
+
+	return e
+}
+
+// MarshalStates will generate an *mqtt.Msg for the state of the fan and each of
+// its other states that have a state callback.
+func (e *FanEntity) MarshalStates(args ...any) ([]*mqttapi.Msg, error) {
+	return marshalStates(e.EntityState, &e.EntityStates, args...)
+}
+
+// MarshalSubscriptions will generate an *mqtt.Subscription for the command
+// topic of the fan and each of its other command topics.
+func (e *FanEntity) MarshalSubscriptions() ([]*mqttapi.Subscription, error) {
+	return marshalSubscriptions(e.EntityCommand, &e.EntityCommands)
+}
+
+func (e *FanEntity) MarshalConfig() (*mqttapi.Msg, error) {
+	var (
+		cfg []byte
+		err error
+	)
+
+	if err = validateEntity(e); err != nil {
+		return nil, fmt.Errorf("entity config is invalid: %w", err)
+	}
+
+	configTopic := discoveryTopic(e.EntityDetails)
+
+	if cfg, err = marshalConfig(e, e.EntityDetails); err != nil {
+		return nil, fmt.Errorf("marshal config: %w", err)
+	}
+
+	return mqttapi.NewMsg(configTopic, cfg), nil
+}
+
+func (e *FanEntity) validate() error {
+	return validateNoSensorStateOptions(e.EntityState, Fan)
+}
+
+func NewFanEntity() *FanEntity {
+	return &FanEntity{}
+}
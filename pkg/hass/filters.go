@@ -0,0 +1,209 @@
+// Copyright (c) 2024 Joshua Rich <joshua.rich@gmail.com>
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package hass
+
+import (
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Filter is applied to the numeric state of an entity before it is published.
+// Apply is passed each new value and returns the filtered value and whether it
+// should be published. Filters are only used by a single entity state and
+// don't need to be safe for concurrent use.
+type Filter interface {
+	Apply(value float64) (float64, bool)
+}
+
+// FilterFunc is an adapter to allow the use of an ordinary function as a
+// Filter.
+type FilterFunc func(value float64) (float64, bool)
+
+// Apply calls f(value).
+func (f FilterFunc) Apply(value float64) (float64, bool) {
+	return f(value)
+}
+
+// Filters adds the given filters to the state of the entity. The state
+// payload is passed through each filter in order before it is published. If
+// any filter drops a value, MarshalState will return ErrFiltered. Filters only
+// apply to state payloads that are a plain number, other payloads (such as
+// None) are published unfiltered.
+func Filters(filters ...Filter) StateOption {
+	return func(e *EntityState) *EntityState {
+		if e.filters == nil {
+			e.filters = &filterPipeline{}
+		}
+
+		e.filters.filters = append(e.filters.filters, filters...)
+
+		return e
+	}
+}
+
+// BinaryThreshold converts the numeric state of the entity, after any filters,
+// into an ON or OFF state, for deriving a binary sensor from a numeric value.
+// The state turns ON when the value reaches upper and OFF when it falls to
+// lower. In between, the state stays as it was, which avoids the state
+// flapping when the value is close to a single threshold. The state is
+// initially OFF.
+func BinaryThreshold(lower, upper float64) StateOption {
+	return func(e *EntityState) *EntityState {
+		if e.filters == nil {
+			e.filters = &filterPipeline{}
+		}
+
+		e.filters.threshold = &threshold{lower: lower, upper: upper}
+
+		return e
+	}
+}
+
+// MovingAverage returns a Filter that publishes the average of the last window
+// values.
+func MovingAverage(window int) Filter {
+	values := make([]float64, 0, window)
+
+	return FilterFunc(func(value float64) (float64, bool) {
+		values = appendWindow(values, value, window)
+
+		var sum float64
+		for _, v := range values {
+			sum += v
+		}
+
+		return sum / float64(len(values)), true
+	})
+}
+
+// Median returns a Filter that publishes the median of the last window values.
+// Compared to MovingAverage, it is less affected by single outlying values.
+func Median(window int) Filter {
+	values := make([]float64, 0, window)
+
+	return FilterFunc(func(value float64) (float64, bool) {
+		values = appendWindow(values, value, window)
+
+		sorted := slices.Sorted(slices.Values(values))
+
+		middle := len(sorted) / 2
+		if len(sorted)%2 == 0 {
+			return (sorted[middle-1] + sorted[middle]) / 2, true
+		}
+
+		return sorted[middle], true
+	})
+}
+
+// Throttle returns a Filter that publishes at most one value per interval.
+// Values received within the interval of the last value published are
+// dropped.
+func Throttle(interval time.Duration) Filter {
+	var last time.Time
+
+	return FilterFunc(func(value float64) (float64, bool) {
+		if !last.IsZero() && time.Since(last) < interval {
+			return value, false
+		}
+
+		last = time.Now()
+
+		return value, true
+	})
+}
+
+// Deadband returns a Filter that only publishes a value when it differs from
+// the last value published by at least delta. Smaller changes are dropped.
+func Deadband(delta float64) Filter {
+	var last *float64
+
+	return FilterFunc(func(value float64) (float64, bool) {
+		if last != nil && math.Abs(value-*last) < delta {
+			return value, false
+		}
+
+		last = &value
+
+		return value, true
+	})
+}
+
+// Clamp returns a Filter that limits values to the range min to max.
+func Clamp(minValue, maxValue float64) Filter {
+	return FilterFunc(func(value float64) (float64, bool) {
+		return max(minValue, min(value, maxValue)), true
+	})
+}
+
+// filterPipeline is the filters and optional threshold applied to a state.
+type filterPipeline struct {
+	threshold *threshold
+	filters   []Filter
+	mu        sync.Mutex
+}
+
+// apply passes the given payload through the filters and threshold. It
+// returns the payload to publish and whether it should be published. Payloads
+// that are not a number are returned as-is.
+func (p *filterPipeline) apply(payload []byte) ([]byte, bool) {
+	value, err := strconv.ParseFloat(strings.TrimSpace(string(payload)), 64)
+	if err != nil || math.IsNaN(value) || math.IsInf(value, 0) {
+		return payload, true
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, filter := range p.filters {
+		var publish bool
+		if value, publish = filter.Apply(value); !publish {
+			return nil, false
+		}
+	}
+
+	if p.threshold != nil {
+		if p.threshold.apply(value) {
+			return []byte(StatePayloadOn), true
+		}
+
+		return []byte(StatePayloadOff), true
+	}
+
+	return []byte(strconv.FormatFloat(value, 'f', -1, 64)), true
+}
+
+// threshold tracks an on/off state derived from a value with hysteresis.
+type threshold struct {
+	lower, upper float64
+	on           bool
+}
+
+// apply updates the state with the given value and returns whether it is on.
+func (t *threshold) apply(value float64) bool {
+	switch {
+	case value >= t.upper:
+		t.on = true
+	case value <= t.lower:
+		t.on = false
+	}
+
+	return t.on
+}
+
+// appendWindow appends the value to the values, dropping the oldest value if
+// there are more than window values.
+func appendWindow(values []float64, value float64, window int) []float64 {
+	values = append(values, value)
+	if len(values) > max(window, 1) {
+		values = values[1:]
+	}
+
+	return values
+}
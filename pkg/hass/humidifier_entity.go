@@ -0,0 +1,235 @@
+// Copyright (c) 2024 Joshua Rich <joshua.rich@gmail.com>
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+//go:generate go run golang.org/x/tools/cmd/stringer -type=HumidifierType -output humidifier_entity_generated.go -linecomment
+package hass
+
+import (
+	"fmt"
+
+	mqttapi "github.com/joshuar/go-hass-anything/v12/pkg/mqtt"
+)
+
+const (
+	HumidifierTypeHumidifier   HumidifierType = iota // humidifier
+	HumidifierTypeDehumidifier                       // dehumidifier
+)
+
+// HumidifierType is the type of humidifier, which defines how it is displayed
+// in Home Assistant.
+type HumidifierType int
+
+// HumidifierEntity represents an entity that controls a humidifier or
+// dehumidifier. Humidifiers are turned on and off through a command topic, and
+// have their target humidity set through a separate command topic. They can
+// optionally support a list of modes. For more details see
+// https://www.home-assistant.io/integrations/humidifier.mqtt/
+//
+//nolint:lll
+type HumidifierEntity struct {
+	*EntityDetails
+	*EntityAvailability
+	*EntityCommand
+	*EntityState
+	*EntityAttributes
+	*EntityModes
+	EntityCommands                `json:"-"`
+	EntityStates                  `json:"-"`
+	HumidifierType                string  `json:"device_class,omitempty"`
+	StateValueTemplate            string  `json:"state_value_template,omitempty"`
+	TargetHumidityCommandTopic    string  `json:"target_humidity_command_topic" validate:"required"`
+	TargetHumidityCommandTemplate string  `json:"target_humidity_command_template,omitempty"`
+	TargetHumidityStateTopic      string  `json:"target_humidity_state_topic,omitempty"`
+	TargetHumidityStateTemplate   string  `json:"target_humidity_state_template,omitempty"`
+	CurrentHumidityTopic          string  `json:"current_humidity_topic,omitempty"`
+	CurrentHumidityTemplate       string  `json:"current_humidity_template,omitempty"`
+	ActionTopic                   string  `json:"action_topic,omitempty"`
+	ActionTemplate                string  `json:"action_template,omitempty"`
+	PayloadOn                     string  `json:"payload_on,omitempty"`
+	PayloadOff                    string  `json:"payload_off,omitempty"`
+	PayloadResetHumidity          string  `json:"payload_reset_humidity,omitempty"`
+	PayloadResetMode              string  `json:"payload_reset_mode,omitempty"`
+	MinHumidity                   float64 `json:"min_humidity,omitempty" validate:"omitempty,gte=0"`
+	MaxHumidity                   float64 `json:"max_humidity,omitempty" validate:"omitempty,lte=100,gtfield=MinHumidity"`
+	Optimistic                    bool    `json:"optimistic,omitempty"`
+}
+
+// OptimisticMode ensures the humidifier works in optimistic mode.
+func (e *HumidifierEntity) OptimisticMode() *HumidifierEntity {
+	e.Optimistic = true
+
+	return e
+}
+
+// WithHumidifierType sets whether the entity is a humidifier or dehumidifier,
+// defining how it gets displayed in Home Assistant. The DeviceClass state
+// option cannot be used for humidifiers.
+func (e *HumidifierEntity) WithHumidifierType(humidifierType HumidifierType) *HumidifierEntity {
+	e.HumidifierType = humidifierType.String()
+
+	return e
+}
+
+// WithOnPayload sets the payload that represents the on state. Defaults to ON.
+func (e *HumidifierEntity) WithOnPayload(payload string) *HumidifierEntity {
+	e.PayloadOn = payload
+
+	return e
+}
+
+// WithOffPayload sets the payload that represents the off state. Defaults to
+// OFF.
+func (e *HumidifierEntity) WithOffPayload(payload string) *HumidifierEntity {
+	e.PayloadOff = payload
+
+	return e
+}
+
+// WithResetPayloads sets the payloads that, when received on the target
+// humidity and mode state topics respectively, reset them to an unknown state.
+func (e *HumidifierEntity) WithResetPayloads(humidity, mode string) *HumidifierEntity {
+	e.PayloadResetHumidity = humidity
+	e.PayloadResetMode = mode
+
+	return e
+}
+
+// WithHumidityRange sets the minimum and maximum target humidity.
+//
+//nolint:predeclared
+func (e *HumidifierEntity) WithHumidityRange(min, max float64) *HumidifierEntity {
+	e.MinHumidity = min
+	e.MaxHumidity = max
+
+	return e
+}
+
+func (e *HumidifierEntity) WithDetails(options ...DetailsOption) *HumidifierEntity {
+	e.EntityDetails = WithDetails(Humidifier, options...)
+
+	return e
+}
+
+// WithState configures the state used to report whether the humidifier is on
+// or off.
+func (e *HumidifierEntity) WithState(options ...StateOption) *HumidifierEntity {
+	e.EntityState = WithStateOptions(options...)
+	e.StateTopic = generateTopic("state", e.EntityDetails)
+	// Humidifiers use state_value_template rather than value_template.
+	e.StateValueTemplate = e.ValueTemplate
+	e.ValueTemplate = ""
+
+	return e
+}
+
+// WithCommand configures the command used to turn the humidifier on or off.
+func (e *HumidifierEntity) WithCommand(options ...CommandOption) *HumidifierEntity {
+	e.EntityCommand = WithCommandOptions(options...)
+	e.CommandTopic = generateTopic("set", e.EntityDetails)
+
+	return e
+}
+
+// WithTargetHumidityCommand configures the command used to set the target
+// humidity. It is required.
+func (e *HumidifierEntity) WithTargetHumidityCommand(options ...CommandOption) *HumidifierEntity {
+	command := e.addCommand("target_humidity", e.EntityDetails, options...)
+	e.TargetHumidityCommandTopic = command.CommandTopic
+	e.TargetHumidityCommandTemplate = command.CommandTemplate
+
+	return e
+}
+
+// WithTargetHumidityState configures the state used to report the target
+// humidity.
+func (e *HumidifierEntity) WithTargetHumidityState(options ...StateOption) *HumidifierEntity {
+	state := e.addState("target_humidity", e.EntityDetails, options...)
+	e.TargetHumidityStateTopic = state.StateTopic
+	e.TargetHumidityStateTemplate = state.ValueTemplate
+
+	return e
+}
+
+// WithCurrentHumidityState configures the state used to report the current
+// (measured) humidity.
+func (e *HumidifierEntity) WithCurrentHumidityState(options ...StateOption) *HumidifierEntity {
+	state := e.addState("current_humidity", e.EntityDetails, options...)
+	e.CurrentHumidityTopic = state.StateTopic
+	e.CurrentHumidityTemplate = state.ValueTemplate
+
+	return e
+}
+
+// WithActionState configures the state used to report the current action of
+// the humidifier. Valid values are off, humidifying, drying and idle.
+func (e *HumidifierEntity) WithActionState(options ...StateOption) *HumidifierEntity {
+	state := e.addState("action", e.EntityDetails, options...)
+	e.ActionTopic = state.StateTopic
+	e.ActionTemplate = state.ValueTemplate
+
+	return e
+}
+
+// WithModes configures the modes supported by the humidifier, and the command
+// and state used to set and report the current mode.
+func (e *HumidifierEntity) WithModes(options ...ModeOption) *HumidifierEntity {
+	e.EntityModes = newEntityModes(e.EntityDetails, &e.EntityCommands, &e.EntityStates, options...)
+
+	return e
+}
+
+func (e *HumidifierEntity) WithAttributes(options ...AttributeOption) *HumidifierEntity {
+	e.EntityAttributes = WithAttributesOptions(options...)
+	e.AttributesTopic = generateTopic("attributes", e.EntityDetails)
+
+	return e
+}
+
+// WithAvailability configures the topics on which the availability of the
+// entity is reported.
+func (e *HumidifierEntity) WithAvailability(options ...AvailabilityOption) *HumidifierEntity {
+	e.EntityAvailability = WithAvailabilityOptions(options...)
+
+	return e
+}
+
+// MarshalStates will generate an *mqtt.Msg for the state of the humidifier and
+// each of its other states that have a state callback.
+func (e *HumidifierEntity) MarshalStates(args ...any) ([]*mqttapi.Msg, error) {
+	return marshalStates(e.EntityState, &e.EntityStates, args...)
+}
+
+// MarshalSubscriptions will generate an *mqtt.Subscription for the command
+// topic of the humidifier and each of its other command topics.
+func (e *HumidifierEntity) MarshalSubscriptions() ([]*mqttapi.Subscription, error) {
+	return marshalSubscriptions(e.EntityCommand, &e.EntityCommands)
+}
+
+func (e *HumidifierEntity) MarshalConfig() (*mqttapi.Msg, error) {
+	var (
+		cfg []byte
+		err error
+	)
+
+	if err = validateEntity(e); err != nil {
+		return nil, fmt.Errorf("entity config is invalid: %w", err)
+	}
+
+	configTopic := discoveryTopic(e.EntityDetails)
+
+	if cfg, err = marshalConfig(e, e.EntityDetails); err != nil {
+		return nil, fmt.Errorf("marshal config: %w", err)
+	}
+
+	return mqttapi.NewMsg(configTopic, cfg), nil
+}
+
+func (e *HumidifierEntity) validate() error {
+	return validateNoStateDeviceClass(e.EntityState, "WithHumidifierType")
+}
+
+func NewHumidifierEntity() *HumidifierEntity {
+	return &HumidifierEntity{}
+}
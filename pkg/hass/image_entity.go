@@ -6,7 +6,6 @@
 package hass
 
 import (
-	"encoding/json"
 	"fmt"
 
 	mqttapi "github.com/joshuar/go-hass-anything/v12/pkg/mqtt"
@@ -26,6 +25,7 @@ type ImageMode int
 // more details, see https://www.home-assistant.io/integrations/image.mqtt/
 type ImageEntity struct {
 	*EntityDetails
+	*EntityAvailability
 	*EntityAttributes
 	*EntityEncoding
 	ImageTopic  string `json:"image_topic,omitempty" validate:"required_without=URLTopic"`
@@ -95,6 +95,14 @@ func (e *ImageEntity) WithAttributes(options ...AttributeOption) *ImageEntity {
 	return e
 }
 
+// WithAvailability configures the topics on which the availability of the
+// entity is reported.
+func (e *ImageEntity) WithAvailability(options ...AvailabilityOption) *ImageEntity {
+	e.EntityAvailability = WithAvailabilityOptions(options...)
+
+	return e
+}
+
 func (e *ImageEntity) MarshalConfig() (*mqttapi.Msg, error) {
 	var (
 		cfg []byte
@@ -114,9 +122,9 @@ func (e *ImageEntity) MarshalConfig() (*mqttapi.Msg, error) {
 		return nil, fmt.Errorf("entity config is invalid: %w", err)
 	}
 
-	configTopic := generateTopic("config", e.EntityDetails)
+	configTopic := discoveryTopic(e.EntityDetails)
 
-	if cfg, err = json.Marshal(e); err != nil {
+	if cfg, err = marshalConfig(e, e.EntityDetails); err != nil {
 		return nil, fmt.Errorf("marshal config: %w", err)
 	}
 
@@ -0,0 +1,225 @@
+// Copyright (c) 2024 Joshua Rich <joshua.rich@gmail.com>
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+//go:generate go run golang.org/x/tools/cmd/stringer -type=LawnMowerActivity,LawnMowerCommand -output lawn_mower_entity_generated.go -linecomment
+package hass
+
+import (
+	"fmt"
+
+	"github.com/eclipse/paho.golang/paho"
+
+	mqttapi "github.com/joshuar/go-hass-anything/v12/pkg/mqtt"
+)
+
+const (
+	LawnMowerActivityDocked    LawnMowerActivity = iota // docked
+	LawnMowerActivityMowing                             // mowing
+	LawnMowerActivityPaused                             // paused
+	LawnMowerActivityReturning                          // returning
+	LawnMowerActivityError                              // error
+)
+
+// LawnMowerActivity is one of the activities a lawn mower can be performing.
+type LawnMowerActivity int
+
+const (
+	LawnMowerCommandStartMowing LawnMowerCommand = iota // start_mowing
+	LawnMowerCommandPause                               // pause
+	LawnMowerCommandDock                                // dock
+)
+
+// LawnMowerCommand is a command sent to a lawn mower by Home Assistant.
+type LawnMowerCommand int
+
+// lawnMowerTransitions are the valid transitions between lawn mower
+// activities. Any activity can transition to the error activity.
+var lawnMowerTransitions = map[LawnMowerActivity][]LawnMowerActivity{
+	LawnMowerActivityDocked:    {LawnMowerActivityMowing, LawnMowerActivityError},
+	LawnMowerActivityMowing:    {LawnMowerActivityPaused, LawnMowerActivityReturning, LawnMowerActivityDocked, LawnMowerActivityError},
+	LawnMowerActivityPaused:    {LawnMowerActivityMowing, LawnMowerActivityReturning, LawnMowerActivityDocked, LawnMowerActivityError},
+	LawnMowerActivityReturning: {LawnMowerActivityDocked, LawnMowerActivityMowing, LawnMowerActivityPaused, LawnMowerActivityError},
+	LawnMowerActivityError:     {LawnMowerActivityDocked, LawnMowerActivityPaused, LawnMowerActivityReturning},
+}
+
+// LawnMowerEntity represents an entity that controls a robot lawn mower. Each
+// of the start mowing, pause and dock commands has its own command topic. For
+// more details see https://www.home-assistant.io/integrations/lawn_mower.mqtt/
+//
+//nolint:lll
+type LawnMowerEntity struct {
+	*EntityDetails
+	*EntityAvailability
+	*EntityAttributes
+	EntityCommands             `json:"-"`
+	EntityStates               `json:"-"`
+	ActivityStateTopic         string `json:"activity_state_topic,omitempty"`
+	ActivityValueTemplate      string `json:"activity_value_template,omitempty"`
+	StartMowingCommandTopic    string `json:"start_mowing_command_topic,omitempty"`
+	StartMowingCommandTemplate string `json:"start_mowing_command_template,omitempty"`
+	PauseCommandTopic          string `json:"pause_command_topic,omitempty"`
+	PauseCommandTemplate       string `json:"pause_command_template,omitempty"`
+	DockCommandTopic           string `json:"dock_command_topic,omitempty"`
+	DockCommandTemplate        string `json:"dock_command_template,omitempty"`
+	Optimistic                 bool   `json:"optimistic,omitempty"`
+	Retain                     bool   `json:"retain,omitempty"`
+}
+
+// OptimisticMode ensures the lawn mower works in optimistic mode.
+func (e *LawnMowerEntity) OptimisticMode() *LawnMowerEntity {
+	e.Optimistic = true
+
+	return e
+}
+
+// RetainCommands ensures that Home Assistant publishes the commands of the
+// lawn mower as retained messages, so that the last command is received on
+// (re)subscribing.
+func (e *LawnMowerEntity) RetainCommands() *LawnMowerEntity {
+	e.Retain = true
+
+	return e
+}
+
+func (e *LawnMowerEntity) WithDetails(options ...DetailsOption) *LawnMowerEntity {
+	e.EntityDetails = WithDetails(LawnMower, options...)
+
+	return e
+}
+
+// WithActivityState configures the state used to report the current activity
+// of the lawn mower. See MarshalActivity and NewStateMachine.
+func (e *LawnMowerEntity) WithActivityState(options ...StateOption) *LawnMowerEntity {
+	state := e.addState("activity", e.EntityDetails, options...)
+	e.ActivityStateTopic = state.StateTopic
+	e.ActivityValueTemplate = state.ValueTemplate
+
+	return e
+}
+
+// WithStartMowingCommand configures the command used to start mowing.
+func (e *LawnMowerEntity) WithStartMowingCommand(options ...CommandOption) *LawnMowerEntity {
+	command := e.addCommand("start_mowing", e.EntityDetails, options...)
+	e.StartMowingCommandTopic = command.CommandTopic
+	e.StartMowingCommandTemplate = command.CommandTemplate
+
+	return e
+}
+
+// WithPauseCommand configures the command used to pause the lawn mower.
+func (e *LawnMowerEntity) WithPauseCommand(options ...CommandOption) *LawnMowerEntity {
+	command := e.addCommand("pause", e.EntityDetails, options...)
+	e.PauseCommandTopic = command.CommandTopic
+	e.PauseCommandTemplate = command.CommandTemplate
+
+	return e
+}
+
+// WithDockCommand configures the command used to send the lawn mower back to
+// its dock.
+func (e *LawnMowerEntity) WithDockCommand(options ...CommandOption) *LawnMowerEntity {
+	command := e.addCommand("dock", e.EntityDetails, options...)
+	e.DockCommandTopic = command.CommandTopic
+	e.DockCommandTemplate = command.CommandTemplate
+
+	return e
+}
+
+// WithCommandHandler configures the start mowing, pause and dock commands of
+// the lawn mower to pass the LawnMowerCommand received to the given handler.
+// Any of these commands that have already been configured keep their options
+// and only have their callback replaced.
+func (e *LawnMowerEntity) WithCommandHandler(handler func(cmd LawnMowerCommand)) *LawnMowerEntity {
+	commands := []struct {
+		add     func(options ...CommandOption) *LawnMowerEntity
+		name    string
+		command LawnMowerCommand
+	}{
+		{add: e.WithStartMowingCommand, name: "start_mowing", command: LawnMowerCommandStartMowing},
+		{add: e.WithPauseCommand, name: "pause", command: LawnMowerCommandPause},
+		{add: e.WithDockCommand, name: "dock", command: LawnMowerCommandDock},
+	}
+
+	for _, c := range commands {
+		callback := CommandCallback(func(_ *paho.Publish) {
+			handler(c.command)
+		})
+
+		if command, found := e.commands[c.name]; found {
+			callback(command)
+		} else {
+			c.add(callback)
+		}
+	}
+
+	return e
+}
+
+func (e *LawnMowerEntity) WithAttributes(options ...AttributeOption) *LawnMowerEntity {
+	e.EntityAttributes = WithAttributesOptions(options...)
+	e.AttributesTopic = generateTopic("attributes", e.EntityDetails)
+
+	return e
+}
+
+// WithAvailability configures the topics on which the availability of the
+// entity is reported.
+func (e *LawnMowerEntity) WithAvailability(options ...AvailabilityOption) *LawnMowerEntity {
+	e.EntityAvailability = WithAvailabilityOptions(options...)
+
+	return e
+}
+
+// NewStateMachine returns a StateMachine that tracks the activity of the lawn
+// mower, starting in the initial activity. Each valid transition generates a
+// message that can be used to publish the new activity.
+func (e *LawnMowerEntity) NewStateMachine(initial LawnMowerActivity) *StateMachine[LawnMowerActivity] {
+	return newStateMachine(initial, lawnMowerTransitions, e.MarshalActivity)
+}
+
+// MarshalActivity will generate an *mqtt.Msg for the given activity, that can
+// be used to publish the current activity of the lawn mower.
+func (e *LawnMowerEntity) MarshalActivity(activity LawnMowerActivity) (*mqttapi.Msg, error) {
+	if e.ActivityStateTopic == "" {
+		return nil, fmt.Errorf("could not marshal state: %w", ErrNoStateTopic)
+	}
+
+	return mqttapi.NewMsg(e.ActivityStateTopic, []byte(activity.String())), nil
+}
+
+// MarshalStates will generate an *mqtt.Msg for the activity of the lawn mower,
+// if it has a state callback.
+func (e *LawnMowerEntity) MarshalStates(args ...any) ([]*mqttapi.Msg, error) {
+	return e.EntityStates.MarshalStates(args...)
+}
+
+// MarshalSubscriptions will generate an *mqtt.Subscription for each of the
+// command topics of the lawn mower.
+func (e *LawnMowerEntity) MarshalSubscriptions() ([]*mqttapi.Subscription, error) {
+	return e.EntityCommands.MarshalSubscriptions()
+}
+
+func (e *LawnMowerEntity) MarshalConfig() (*mqttapi.Msg, error) {
+	var (
+		cfg []byte
+		err error
+	)
+
+	if err = validateEntity(e); err != nil {
+		return nil, fmt.Errorf("entity config is invalid: %w", err)
+	}
+
+	configTopic := discoveryTopic(e.EntityDetails)
+
+	if cfg, err = marshalConfig(e, e.EntityDetails); err != nil {
+		return nil, fmt.Errorf("marshal config: %w", err)
+	}
+
+	return mqttapi.NewMsg(configTopic, cfg), nil
+}
+
+func NewLawnMowerEntity() *LawnMowerEntity {
+	return &LawnMowerEntity{}
+}
@@ -0,0 +1,241 @@
+// Copyright (c) 2024 Joshua Rich <joshua.rich@gmail.com>
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+//go:generate go run golang.org/x/tools/cmd/stringer -type=LightColorMode -output light_entity_generated.go -linecomment
+package hass
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	mqttapi "github.com/joshuar/go-hass-anything/v12/pkg/mqtt"
+)
+
+const (
+	// ColorModeOnOff is a light that can only be turned on and off.
+	ColorModeOnOff LightColorMode = iota // onoff
+	// ColorModeBrightness is a light that can be dimmed.
+	ColorModeBrightness // brightness
+	// ColorModeColorTemp is a light that can change its color temperature.
+	ColorModeColorTemp // color_temp
+	// ColorModeHS is a light that can change its color using hue and saturation.
+	ColorModeHS // hs
+	// ColorModeXY is a light that can change its color using CIE xy coordinates.
+	ColorModeXY // xy
+	// ColorModeRGB is a light that can change its color using RGB values.
+	ColorModeRGB // rgb
+	// ColorModeRGBW is a light that can change its color using RGBW values.
+	ColorModeRGBW // rgbw
+	// ColorModeRGBWW is a light that can change its color using RGBWW values.
+	ColorModeRGBWW // rgbww
+	// ColorModeWhite is a light that can be switched to a white mode.
+	ColorModeWhite // white
+)
+
+// LightColorMode is a color mode that a light supports.
+type LightColorMode int
+
+// LightEntity represents an entity that controls a light, using the JSON
+// schema for commands and state. For more details see
+// https://www.home-assistant.io/integrations/light.mqtt/#json-schema
+type LightEntity struct {
+	*EntityDetails
+	*EntityAvailability
+	*EntityCommand
+	*EntityState
+	*EntityAttributes
+	Schema              string   `json:"schema" validate:"eq=json"`
+	SupportedColorModes []string `json:"supported_color_modes,omitempty"`
+	EffectList          []string `json:"effect_list,omitempty" validate:"required_if=Effect true"`
+	BrightnessScale     int      `json:"brightness_scale,omitempty" validate:"omitempty,gt=0"`
+	MinKelvin           int      `json:"min_kelvin,omitempty" validate:"omitempty,gt=0"`
+	MaxKelvin           int      `json:"max_kelvin,omitempty" validate:"omitempty,gtfield=MinKelvin"`
+	FlashTimeShort      int      `json:"flash_time_short,omitempty" validate:"omitempty,gte=0"`
+	FlashTimeLong       int      `json:"flash_time_long,omitempty" validate:"omitempty,gte=0"`
+	Brightness          bool     `json:"brightness,omitempty"`
+	ColorTempKelvin     bool     `json:"color_temp_kelvin,omitempty"`
+	Effect              bool     `json:"effect,omitempty"`
+	Optimistic          bool     `json:"optimistic,omitempty"`
+}
+
+// LightColor represents the color values of a light. Only the values relevant
+// for the color mode in use will be set.
+type LightColor struct {
+	R *int     `json:"r,omitempty"`
+	G *int     `json:"g,omitempty"`
+	B *int     `json:"b,omitempty"`
+	C *int     `json:"c,omitempty"`
+	W *int     `json:"w,omitempty"`
+	H *float64 `json:"h,omitempty"`
+	S *float64 `json:"s,omitempty"`
+	X *float64 `json:"x,omitempty"`
+	Y *float64 `json:"y,omitempty"`
+}
+
+// LightState represents the JSON payload of a light. It is used both for the
+// commands received from Home Assistant and the state published back to it.
+// Fields that are not relevant to a particular command or state will be nil or
+// empty.
+type LightState struct {
+	Color      *LightColor `json:"color,omitempty"`
+	Brightness *int        `json:"brightness,omitempty"`
+	// ColorTemp is in Kelvin when the light has been configured with
+	// WithColorTempRange, otherwise it is in mireds.
+	ColorTemp  *int     `json:"color_temp,omitempty"`
+	White      *int     `json:"white,omitempty"`
+	Transition *float64 `json:"transition,omitempty"`
+	State      string   `json:"state"`
+	ColorMode  string   `json:"color_mode,omitempty"`
+	Effect     string   `json:"effect,omitempty"`
+	Flash      string   `json:"flash,omitempty"`
+}
+
+// IsOn returns a boolean indicating whether the state is "ON".
+func (s *LightState) IsOn() bool {
+	return s.State == "ON"
+}
+
+// LightCommandCallback will add the passed in function as the callback action
+// to be run whenever a command is received for a light entity. The JSON
+// payload of the command is decoded into a LightState before being passed to
+// the function. Payloads that cannot be decoded are logged and ignored.
+func LightCommandCallback(callback func(cmd *LightState)) CommandOption {
+	return CommandCallback(decodeCommand(decodeJSON[LightState], callback))
+}
+
+// OptimisticMode ensures the light works in optimistic mode.
+func (e *LightEntity) OptimisticMode() *LightEntity {
+	e.Optimistic = true
+
+	return e
+}
+
+// WithColorModes sets the color modes supported by the light. Note that
+// ColorModeOnOff and ColorModeBrightness cannot be combined with any other
+// color mode.
+func (e *LightEntity) WithColorModes(modes ...LightColorMode) *LightEntity {
+	e.SupportedColorModes = make([]string, 0, len(modes))
+
+	for _, mode := range modes {
+		e.SupportedColorModes = append(e.SupportedColorModes, mode.String())
+	}
+
+	return e
+}
+
+// WithBrightness marks the light as supporting brightness, with the given
+// scale. The scale defines the maximum brightness value (i.e., 100%) used in
+// commands and state. If 0, Home Assistant will use its default of 255.
+func (e *LightEntity) WithBrightness(scale int) *LightEntity {
+	e.Brightness = true
+	e.BrightnessScale = scale
+
+	return e
+}
+
+// WithColorTempRange sets the minimum and maximum color temperature, in Kelvin,
+// that the light supports. Color temperatures in commands and state will also
+// be in Kelvin.
+func (e *LightEntity) WithColorTempRange(minKelvin, maxKelvin int) *LightEntity {
+	e.ColorTempKelvin = true
+	e.MinKelvin = minKelvin
+	e.MaxKelvin = maxKelvin
+
+	return e
+}
+
+// WithEffects sets the list of effects that the light supports.
+func (e *LightEntity) WithEffects(effects ...string) *LightEntity {
+	e.Effect = true
+	e.EffectList = effects
+
+	return e
+}
+
+// WithFlashTimes sets the duration of the short and long flashes the light
+// will perform when requested.
+func (e *LightEntity) WithFlashTimes(short, long time.Duration) *LightEntity {
+	e.FlashTimeShort = int(short.Seconds())
+	e.FlashTimeLong = int(long.Seconds())
+
+	return e
+}
+
+func (e *LightEntity) WithDetails(options ...DetailsOption) *LightEntity {
+	e.EntityDetails = WithDetails(Light, options...)
+
+	return e
+}
+
+func (e *LightEntity) WithState(options ...StateOption) *LightEntity {
+	e.EntityState = WithStateOptions(options...)
+	e.StateTopic = generateTopic("state", e.EntityDetails)
+
+	return e
+}
+
+func (e *LightEntity) WithCommand(options ...CommandOption) *LightEntity {
+	e.EntityCommand = WithCommandOptions(options...)
+	e.CommandTopic = generateTopic("set", e.EntityDetails)
+
+	return e
+}
+
+func (e *LightEntity) WithAttributes(options ...AttributeOption) *LightEntity {
+	e.EntityAttributes = WithAttributesOptions(options...)
+	e.AttributesTopic = generateTopic("attributes", e.EntityDetails)
+
+	return e
+}
+
+// WithAvailability configures the topics on which the availability of the
+// entity is reported.
+func (e *LightEntity) WithAvailability(options ...AvailabilityOption) *LightEntity {
+	e.EntityAvailability = WithAvailabilityOptions(options...)
+
+	return e
+}
+
+// MarshalLightState will generate an *mqtt.Msg for the given light state, that
+// can be used to publish the state to the light's state topic. It can be used
+// in place of a state callback.
+func (e *LightEntity) MarshalLightState(state *LightState) (*mqttapi.Msg, error) {
+	if e.EntityState == nil {
+		return nil, fmt.Errorf("could not marshal state: %w", ErrNoStateTopic)
+	}
+
+	payload, err := json.Marshal(state)
+	if err != nil {
+		return nil, fmt.Errorf("could not marshal state: %w", err)
+	}
+
+	return mqttapi.NewMsg(e.StateTopic, payload), nil
+}
+
+func (e *LightEntity) MarshalConfig() (*mqttapi.Msg, error) {
+	var (
+		cfg []byte
+		err error
+	)
+
+	if err = validateEntity(e); err != nil {
+		return nil, fmt.Errorf("entity config is invalid: %w", err)
+	}
+
+	configTopic := discoveryTopic(e.EntityDetails)
+
+	if cfg, err = marshalConfig(e, e.EntityDetails); err != nil {
+		return nil, fmt.Errorf("marshal config: %w", err)
+	}
+
+	return mqttapi.NewMsg(configTopic, cfg), nil
+}
+
+func NewLightEntity() *LightEntity {
+	return &LightEntity{
+		Schema: "json",
+	}
+}
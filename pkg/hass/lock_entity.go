@@ -0,0 +1,278 @@
+// Copyright (c) 2024 Joshua Rich <joshua.rich@gmail.com>
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+//go:generate go run golang.org/x/tools/cmd/stringer -type=LockAction,LockState -output lock_entity_generated.go -linecomment
+package hass
+
+import (
+	"encoding/json"
+	"fmt"
+
+	mqttapi "github.com/joshuar/go-hass-anything/v12/pkg/mqtt"
+)
+
+const (
+	LockActionLock   LockAction = iota // LOCK
+	LockActionUnlock                   // UNLOCK
+	LockActionOpen                     // OPEN
+)
+
+// LockAction is an action requested of a lock by Home Assistant.
+type LockAction int
+
+const (
+	LockStateLocked    LockState = iota // LOCKED
+	LockStateUnlocked                   // UNLOCKED
+	LockStateLocking                    // LOCKING
+	LockStateUnlocking                  // UNLOCKING
+	LockStateJammed                     // JAMMED
+	LockStateOpen                       // OPEN
+	LockStateOpening                    // OPENING
+)
+
+// LockState is one of the states a lock can be in.
+type LockState int
+
+// lockCodeTemplate is the command template used when the lock requires a code,
+// so that the code entered in Home Assistant is passed along with the action.
+const lockCodeTemplate = `{{ {'action': value, 'code': code} | to_json }}`
+
+// LockCommand represents a command received for a lock entity.
+type LockCommand struct {
+	// Code is the code entered in Home Assistant. It is only set when the lock
+	// has been configured with a code format.
+	Code string
+	// Action is the requested action.
+	Action LockAction
+}
+
+// LockEntity represents an entity that controls a lock. For more details see
+// https://www.home-assistant.io/integrations/lock.mqtt/
+type LockEntity struct {
+	*EntityDetails
+	*EntityAvailability
+	*EntityCommand
+	*EntityState
+	*EntityAttributes
+	CodeFormat     string `json:"code_format,omitempty"`
+	PayloadLock    string `json:"payload_lock,omitempty"`
+	PayloadUnlock  string `json:"payload_unlock,omitempty"`
+	PayloadOpen    string `json:"payload_open,omitempty"`
+	PayloadReset   string `json:"payload_reset,omitempty"`
+	StateLocked    string `json:"state_locked,omitempty"`
+	StateUnlocked  string `json:"state_unlocked,omitempty"`
+	StateLocking   string `json:"state_locking,omitempty"`
+	StateUnlocking string `json:"state_unlocking,omitempty"`
+	StateJammed    string `json:"state_jammed,omitempty"`
+	StateOpen      string `json:"state_open,omitempty"`
+	StateOpening   string `json:"state_opening,omitempty"`
+	Optimistic     bool   `json:"optimistic,omitempty"`
+}
+
+// OptimisticMode ensures the lock works in optimistic mode.
+func (e *LockEntity) OptimisticMode() *LockEntity {
+	e.Optimistic = true
+
+	return e
+}
+
+// WithCodeFormat sets a regular expression that a code entered in Home
+// Assistant must match before a command is sent. When set, the code will be
+// passed along with the command and made available in the LockCommand received
+// by a command handler.
+func (e *LockEntity) WithCodeFormat(format string) *LockEntity {
+	e.CodeFormat = format
+	e.defaultCommandTemplate()
+
+	return e
+}
+
+// WithActionPayload sets the payload sent to the command topic for the given
+// action. By default, the payload for each action is LOCK, UNLOCK and OPEN
+// respectively. Note that the lock will only support being opened if a payload
+// for LockActionOpen has been set.
+func (e *LockEntity) WithActionPayload(action LockAction, payload string) *LockEntity {
+	switch action {
+	case LockActionLock:
+		e.PayloadLock = payload
+	case LockActionUnlock:
+		e.PayloadUnlock = payload
+	case LockActionOpen:
+		e.PayloadOpen = payload
+	}
+
+	return e
+}
+
+// WithStatePayload sets the payload that represents the given state on the
+// state topic. By default, the payload for each state is its name in upper case
+// (i.e., "LOCKED", "JAMMED", etc.).
+func (e *LockEntity) WithStatePayload(state LockState, payload string) *LockEntity {
+	switch state {
+	case LockStateLocked:
+		e.StateLocked = payload
+	case LockStateUnlocked:
+		e.StateUnlocked = payload
+	case LockStateLocking:
+		e.StateLocking = payload
+	case LockStateUnlocking:
+		e.StateUnlocking = payload
+	case LockStateJammed:
+		e.StateJammed = payload
+	case LockStateOpen:
+		e.StateOpen = payload
+	case LockStateOpening:
+		e.StateOpening = payload
+	}
+
+	return e
+}
+
+// WithResetPayload defines a special payload that resets the state to unknown
+// when received on the state_topic.
+func (e *LockEntity) WithResetPayload(payload string) *LockEntity {
+	e.PayloadReset = payload
+
+	return e
+}
+
+func (e *LockEntity) WithDetails(options ...DetailsOption) *LockEntity {
+	e.EntityDetails = WithDetails(Lock, options...)
+
+	return e
+}
+
+func (e *LockEntity) WithState(options ...StateOption) *LockEntity {
+	e.EntityState = WithStateOptions(options...)
+	e.StateTopic = generateTopic("state", e.EntityDetails)
+
+	return e
+}
+
+func (e *LockEntity) WithCommand(options ...CommandOption) *LockEntity {
+	e.EntityCommand = WithCommandOptions(options...)
+	e.CommandTopic = generateTopic("set", e.EntityDetails)
+	e.defaultCommandTemplate()
+
+	return e
+}
+
+// defaultCommandTemplate ensures that, if a code is required, it is sent along
+// with the action so that it can be decoded. Any command template that has
+// been set is kept.
+func (e *LockEntity) defaultCommandTemplate() {
+	if e.CodeFormat != "" && e.EntityCommand != nil && e.CommandTemplate == "" {
+		e.CommandTemplate = lockCodeTemplate
+	}
+}
+
+// WithCommandHandler configures the command of the lock to pass each command
+// received, decoded as a LockCommand, to the given handler. Commands that do
+// not match any of the configured action payloads are logged and ignored.
+func (e *LockEntity) WithCommandHandler(handler func(cmd *LockCommand), options ...CommandOption) *LockEntity {
+	options = append(options, CommandCallback(decodeCommand(e.decodeCommand, handler)))
+
+	return e.WithCommand(options...)
+}
+
+func (e *LockEntity) WithAttributes(options ...AttributeOption) *LockEntity {
+	e.EntityAttributes = WithAttributesOptions(options...)
+	e.AttributesTopic = generateTopic("attributes", e.EntityDetails)
+
+	return e
+}
+
+// WithAvailability configures the topics on which the availability of the
+// entity is reported.
+func (e *LockEntity) WithAvailability(options ...AvailabilityOption) *LockEntity {
+	e.EntityAvailability = WithAvailabilityOptions(options...)
+
+	return e
+}
+
+// MarshalLockState will generate an *mqtt.Msg for the given lock state, using
+// the payload configured for that state, that can be used to publish the state
+// of the lock.
+func (e *LockEntity) MarshalLockState(state LockState) (*mqttapi.Msg, error) {
+	if e.EntityState == nil {
+		return nil, fmt.Errorf("could not marshal state: %w", ErrNoStateTopic)
+	}
+
+	var payload string
+
+	switch state {
+	case LockStateLocked:
+		payload = e.StateLocked
+	case LockStateUnlocked:
+		payload = e.StateUnlocked
+	case LockStateLocking:
+		payload = e.StateLocking
+	case LockStateUnlocking:
+		payload = e.StateUnlocking
+	case LockStateJammed:
+		payload = e.StateJammed
+	case LockStateOpen:
+		payload = e.StateOpen
+	case LockStateOpening:
+		payload = e.StateOpening
+	}
+
+	return mqttapi.NewMsg(e.StateTopic, []byte(payloadOrDefault(payload, state.String()))), nil
+}
+
+func (e *LockEntity) MarshalConfig() (*mqttapi.Msg, error) {
+	var (
+		cfg []byte
+		err error
+	)
+
+	if err = validateEntity(e); err != nil {
+		return nil, fmt.Errorf("entity config is invalid: %w", err)
+	}
+
+	configTopic := discoveryTopic(e.EntityDetails)
+
+	if cfg, err = marshalConfig(e, e.EntityDetails); err != nil {
+		return nil, fmt.Errorf("marshal config: %w", err)
+	}
+
+	return mqttapi.NewMsg(configTopic, cfg), nil
+}
+
+// decodeCommand converts a command payload into a LockCommand. The payload is
+// either the action payload on its own or, if the lock requires a code, a JSON
+// object containing the action payload and code.
+func (e *LockEntity) decodeCommand(payload []byte) (*LockCommand, error) {
+	var (
+		command  = &LockCommand{}
+		withCode struct {
+			Action string `json:"action"`
+			Code   string `json:"code"`
+		}
+		action = string(payload)
+	)
+
+	if err := json.Unmarshal(payload, &withCode); err == nil && withCode.Action != "" {
+		action = withCode.Action
+		command.Code = withCode.Code
+	}
+
+	switch action {
+	case payloadOrDefault(e.PayloadLock, LockActionLock.String()):
+		command.Action = LockActionLock
+	case payloadOrDefault(e.PayloadUnlock, LockActionUnlock.String()):
+		command.Action = LockActionUnlock
+	case payloadOrDefault(e.PayloadOpen, LockActionOpen.String()):
+		command.Action = LockActionOpen
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownPayload, action)
+	}
+
+	return command, nil
+}
+
+func NewLockEntity() *LockEntity {
+	return &LockEntity{}
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2024 Joshua Rich <joshua.rich@gmail.com>
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package hass
+
+import (
+	"fmt"
+
+	"github.com/eclipse/paho.golang/paho"
+
+	mqttapi "github.com/joshuar/go-hass-anything/v12/pkg/mqtt"
+)
+
+// NotifyEntity represents an entity that receives notifications from Home
+// Assistant. The notification message is sent to the command topic. For more
+// details see https://www.home-assistant.io/integrations/notify.mqtt/
+type NotifyEntity struct {
+	*EntityDetails
+	*EntityAvailability
+	*EntityCommand
+	*EntityAttributes
+}
+
+func (e *NotifyEntity) WithDetails(options ...DetailsOption) *NotifyEntity {
+	e.EntityDetails = WithDetails(Notify, options...)
+
+	return e
+}
+
+func (e *NotifyEntity) WithCommand(options ...CommandOption) *NotifyEntity {
+	e.EntityCommand = WithCommandOptions(options...)
+	e.CommandTopic = generateTopic("set", e.EntityDetails)
+
+	return e
+}
+
+// WithNotificationHandler configures the command of the notify entity to pass
+// the message of each notification received to the given handler.
+func (e *NotifyEntity) WithNotificationHandler(handler func(message string), options ...CommandOption) *NotifyEntity {
+	options = append(options, CommandCallback(func(p *paho.Publish) {
+		handler(string(p.Payload))
+	}))
+
+	return e.WithCommand(options...)
+}
+
+func (e *NotifyEntity) WithAttributes(options ...AttributeOption) *NotifyEntity {
+	e.EntityAttributes = WithAttributesOptions(options...)
+	e.AttributesTopic = generateTopic("attributes", e.EntityDetails)
+
+	return e
+}
+
+// WithAvailability configures the topics on which the availability of the
+// entity is reported.
+func (e *NotifyEntity) WithAvailability(options ...AvailabilityOption) *NotifyEntity {
+	e.EntityAvailability = WithAvailabilityOptions(options...)
+
+	return e
+}
+
+func (e *NotifyEntity) MarshalConfig() (*mqttapi.Msg, error) {
+	var (
+		cfg []byte
+		err error
+	)
+
+	if err = validateEntity(e); err != nil {
+		return nil, fmt.Errorf("entity config is invalid: %w", err)
+	}
+
+	configTopic := discoveryTopic(e.EntityDetails)
+
+	if cfg, err = marshalConfig(e, e.EntityDetails); err != nil {
+		return nil, fmt.Errorf("marshal config: %w", err)
+	}
+
+	return mqttapi.NewMsg(configTopic, cfg), nil
+}
+
+func NewNotifyEntity() *NotifyEntity {
+	return &NotifyEntity{}
+}
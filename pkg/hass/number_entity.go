@@ -6,8 +6,10 @@
 package hass
 
 import (
-	"encoding/json"
 	"fmt"
+	"math"
+	"reflect"
+	"strconv"
 
 	"golang.org/x/exp/constraints"
 
@@ -38,6 +40,7 @@ type NumberEntity[T constraints.Ordered] struct {
 	Max  T `json:"max,omitempty"`
 	Step T `json:"step,omitempty"`
 	*EntityDetails
+	*EntityAvailability
 	*EntityState
 	*EntityCommand
 	*EntityAttributes
@@ -116,6 +119,16 @@ func (e *NumberEntity[T]) WithCommand(options ...CommandOption) *NumberEntity[T]
 	return e
 }
 
+// WithCommandHandler configures the command of the number entity to pass each
+// value received, converted to the type of the number entity, to the given
+// handler. Values that cannot be converted or, if a minimum and maximum are
+// set, are outside of that range are logged and ignored.
+func (e *NumberEntity[T]) WithCommandHandler(handler func(value T), options ...CommandOption) *NumberEntity[T] {
+	options = append(options, CommandCallback(decodeCommand(e.decodeCommand, handler)))
+
+	return e.WithCommand(options...)
+}
+
 func (e *NumberEntity[T]) WithAttributes(options ...AttributeOption) *NumberEntity[T] {
 	e.EntityAttributes = WithAttributesOptions(options...)
 	e.AttributesTopic = generateTopic("attributes", e.EntityDetails)
@@ -123,6 +136,14 @@ func (e *NumberEntity[T]) WithAttributes(options ...AttributeOption) *NumberEnti
 	return e
 }
 
+// WithAvailability configures the topics on which the availability of the
+// entity is reported.
+func (e *NumberEntity[T]) WithAvailability(options ...AvailabilityOption) *NumberEntity[T] {
+	e.EntityAvailability = WithAvailabilityOptions(options...)
+
+	return e
+}
+
 func (e *NumberEntity[T]) MarshalConfig() (*mqttapi.Msg, error) {
 	var (
 		cfg []byte
@@ -133,15 +154,80 @@ func (e *NumberEntity[T]) MarshalConfig() (*mqttapi.Msg, error) {
 		return nil, fmt.Errorf("entity config is invalid: %w", err)
 	}
 
-	configTopic := generateTopic("config", e.EntityDetails)
+	configTopic := discoveryTopic(e.EntityDetails)
 
-	if cfg, err = json.Marshal(e); err != nil {
+	if cfg, err = marshalConfig(e, e.EntityDetails); err != nil {
 		return nil, fmt.Errorf("marshal config: %w", err)
 	}
 
 	return mqttapi.NewMsg(configTopic, cfg), nil
 }
 
+// decodeCommand converts a command payload into a value of the type of the
+// number entity. Home Assistant may send integer values with a decimal point
+// (i.e., "50.0"), which are accepted for integer types if they have no
+// fractional part.
+//
+//nolint:exhaustive
+func (e *NumberEntity[T]) decodeCommand(payload []byte) (T, error) {
+	var value T
+
+	number := reflect.ValueOf(&value).Elem()
+
+	switch number.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(string(payload), 10, number.Type().Bits())
+		if err != nil {
+			f, floatErr := strconv.ParseFloat(string(payload), 64)
+			if floatErr != nil || f != math.Trunc(f) || number.OverflowInt(int64(f)) {
+				return value, fmt.Errorf("%w: %s", ErrUnknownPayload, string(payload))
+			}
+
+			n = int64(f)
+		}
+
+		number.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := strconv.ParseUint(string(payload), 10, number.Type().Bits())
+		if err != nil {
+			f, floatErr := strconv.ParseFloat(string(payload), 64)
+			if floatErr != nil || f < 0 || f != math.Trunc(f) || number.OverflowUint(uint64(f)) {
+				return value, fmt.Errorf("%w: %s", ErrUnknownPayload, string(payload))
+			}
+
+			n = uint64(f)
+		}
+
+		number.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(string(payload), number.Type().Bits())
+		if err != nil {
+			return value, fmt.Errorf("%w: %s", ErrUnknownPayload, string(payload))
+		}
+
+		number.SetFloat(f)
+	case reflect.String:
+		number.SetString(string(payload))
+	}
+
+	if e.Min < e.Max && (value < e.Min || value > e.Max) {
+		return value, fmt.Errorf("%w: %v is not between %v and %v", ErrInvalidValue, value, e.Min, e.Max)
+	}
+
+	return value, nil
+}
+
+// validate checks the device class and units of the number entity are a
+// combination that Home Assistant accepts. Numbers use the same device classes
+// as sensors, except those that are not numeric.
+func (e *NumberEntity[T]) validate() error {
+	if e.EntityState == nil {
+		return nil
+	}
+
+	return e.validateSensor(Number)
+}
+
 func NewNumberEntity[T constraints.Ordered]() *NumberEntity[T] {
 	return &NumberEntity[T]{}
 }
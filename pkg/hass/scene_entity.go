@@ -0,0 +1,107 @@
+// Copyright (c) 2024 Joshua Rich <joshua.rich@gmail.com>
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package hass
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/eclipse/paho.golang/paho"
+
+	mqttapi "github.com/joshuar/go-hass-anything/v12/pkg/mqtt"
+)
+
+// ScenePayloadOn is the default payload sent to activate a scene.
+const ScenePayloadOn = "ON"
+
+// SceneEntity represents an entity that activates a scene. Scenes have no
+// state; they can only be activated. For more details see
+// https://www.home-assistant.io/integrations/scene.mqtt/
+type SceneEntity struct {
+	*EntityDetails
+	*EntityAvailability
+	*EntityCommand
+	*EntityAttributes
+	PayloadOn string `json:"payload_on,omitempty"`
+}
+
+// WithActivatePayload sets the payload sent to the command topic to activate
+// the scene. Defaults to ON.
+func (e *SceneEntity) WithActivatePayload(payload string) *SceneEntity {
+	e.PayloadOn = payload
+
+	return e
+}
+
+func (e *SceneEntity) WithDetails(options ...DetailsOption) *SceneEntity {
+	e.EntityDetails = WithDetails(Scene, options...)
+
+	return e
+}
+
+func (e *SceneEntity) WithCommand(options ...CommandOption) *SceneEntity {
+	e.EntityCommand = WithCommandOptions(options...)
+	e.CommandTopic = generateTopic("activate", e.EntityDetails)
+
+	return e
+}
+
+// WithActivateHandler configures the command of the scene to run the given
+// handler when the scene is activated. Any payload other than the activate
+// payload is logged and ignored.
+func (e *SceneEntity) WithActivateHandler(handler func(), options ...CommandOption) *SceneEntity {
+	options = append(options, CommandCallback(func(p *paho.Publish) {
+		if string(p.Payload) != payloadOrDefault(e.PayloadOn, ScenePayloadOn) {
+			slog.Warn("Could not decode command payload.",
+				slog.String("topic", p.Topic),
+				slog.Any("error", fmt.Errorf("%w: %s", ErrUnknownPayload, string(p.Payload))))
+
+			return
+		}
+
+		handler()
+	}))
+
+	return e.WithCommand(options...)
+}
+
+func (e *SceneEntity) WithAttributes(options ...AttributeOption) *SceneEntity {
+	e.EntityAttributes = WithAttributesOptions(options...)
+	e.AttributesTopic = generateTopic("attributes", e.EntityDetails)
+
+	return e
+}
+
+// WithAvailability configures the topics on which the availability of the
+// entity is reported.
+func (e *SceneEntity) WithAvailability(options ...AvailabilityOption) *SceneEntity {
+	e.EntityAvailability = WithAvailabilityOptions(options...)
+
+	return e
+}
+
+func (e *SceneEntity) MarshalConfig() (*mqttapi.Msg, error) {
+	var (
+		cfg []byte
+		err error
+	)
+
+	if err = validateEntity(e); err != nil {
+		return nil, fmt.Errorf("entity config is invalid: %w", err)
+	}
+
+	configTopic := discoveryTopic(e.EntityDetails)
+
+	if cfg, err = marshalConfig(e, e.EntityDetails); err != nil {
+		return nil, fmt.Errorf("marshal config: %w", err)
+	}
+
+	return mqttapi.NewMsg(configTopic, cfg), nil
+}
+
+func NewSceneEntity() *SceneEntity {
+	return &SceneEntity{}
+}
@@ -0,0 +1,117 @@
+// Copyright (c) 2024 Joshua Rich <joshua.rich@gmail.com>
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package hass
+
+import (
+	"fmt"
+	"slices"
+
+	mqttapi "github.com/joshuar/go-hass-anything/v12/pkg/mqtt"
+)
+
+// SelectEntity represents an entity that can be set to one of a fixed list of
+// options. For more details see
+// https://www.home-assistant.io/integrations/select.mqtt/
+type SelectEntity struct {
+	*EntityDetails
+	*EntityAvailability
+	*EntityCommand
+	*EntityState
+	*EntityAttributes
+	Options    []string `json:"options" validate:"required,unique"`
+	Optimistic bool     `json:"optimistic,omitempty"`
+}
+
+// OptimisticMode ensures the select works in optimistic mode.
+func (e *SelectEntity) OptimisticMode() *SelectEntity {
+	e.Optimistic = true
+
+	return e
+}
+
+// WithOptions sets the list of options that can be selected. The state
+// published for the entity should always be one of these options.
+func (e *SelectEntity) WithOptions(options ...string) *SelectEntity {
+	e.Options = options
+
+	return e
+}
+
+func (e *SelectEntity) WithDetails(options ...DetailsOption) *SelectEntity {
+	e.EntityDetails = WithDetails(Select, options...)
+
+	return e
+}
+
+func (e *SelectEntity) WithState(options ...StateOption) *SelectEntity {
+	e.EntityState = WithStateOptions(options...)
+	e.StateTopic = generateTopic("state", e.EntityDetails)
+
+	return e
+}
+
+func (e *SelectEntity) WithCommand(options ...CommandOption) *SelectEntity {
+	e.EntityCommand = WithCommandOptions(options...)
+	e.CommandTopic = generateTopic("set", e.EntityDetails)
+
+	return e
+}
+
+// WithCommandHandler configures the command of the select to pass each option
+// selected to the given handler. Any option that is not one of the options of
+// the select is logged and ignored.
+func (e *SelectEntity) WithCommandHandler(handler func(option string), options ...CommandOption) *SelectEntity {
+	options = append(options, CommandCallback(decodeCommand(e.decodeCommand, handler)))
+
+	return e.WithCommand(options...)
+}
+
+func (e *SelectEntity) WithAttributes(options ...AttributeOption) *SelectEntity {
+	e.EntityAttributes = WithAttributesOptions(options...)
+	e.AttributesTopic = generateTopic("attributes", e.EntityDetails)
+
+	return e
+}
+
+// WithAvailability configures the topics on which the availability of the
+// entity is reported.
+func (e *SelectEntity) WithAvailability(options ...AvailabilityOption) *SelectEntity {
+	e.EntityAvailability = WithAvailabilityOptions(options...)
+
+	return e
+}
+
+func (e *SelectEntity) MarshalConfig() (*mqttapi.Msg, error) {
+	var (
+		cfg []byte
+		err error
+	)
+
+	if err = validateEntity(e); err != nil {
+		return nil, fmt.Errorf("entity config is invalid: %w", err)
+	}
+
+	configTopic := discoveryTopic(e.EntityDetails)
+
+	if cfg, err = marshalConfig(e, e.EntityDetails); err != nil {
+		return nil, fmt.Errorf("marshal config: %w", err)
+	}
+
+	return mqttapi.NewMsg(configTopic, cfg), nil
+}
+
+// decodeCommand checks a command payload is one of the options of the select.
+func (e *SelectEntity) decodeCommand(payload []byte) (string, error) {
+	if !slices.Contains(e.Options, string(payload)) {
+		return "", fmt.Errorf("%w: %s", ErrUnknownPayload, string(payload))
+	}
+
+	return string(payload), nil
+}
+
+func NewSelectEntity() *SelectEntity {
+	return &SelectEntity{}
+}
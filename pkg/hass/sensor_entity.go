@@ -3,22 +3,254 @@
 // This software is released under the MIT License.
 // https://opensource.org/licenses/MIT
 
+//go:generate go run golang.org/x/tools/cmd/stringer -type=SensorType,BinarySensorType -output sensor_entity_generated.go -linecomment
 package hass
 
 import (
-	"encoding/json"
+	"errors"
 	"fmt"
+	"slices"
 	"time"
 
 	mqttapi "github.com/joshuar/go-hass-anything/v12/pkg/mqtt"
 )
 
+const (
+	SensorTypeNone                          SensorType = iota //
+	SensorTypeApparentPower                                   // apparent_power
+	SensorTypeAQI                                             // aqi
+	SensorTypeAbsoluteHumidity                                // absolute_humidity
+	SensorTypeArea                                            // area
+	SensorTypeAtmosphericPressure                             // atmospheric_pressure
+	SensorTypeBattery                                         // battery
+	SensorTypeBloodGlucoseConcentration                       // blood_glucose_concentration
+	SensorTypeCarbonDioxide                                   // carbon_dioxide
+	SensorTypeCarbonMonoxide                                  // carbon_monoxide
+	SensorTypeConductivity                                    // conductivity
+	SensorTypeCurrent                                         // current
+	SensorTypeDataRate                                        // data_rate
+	SensorTypeDataSize                                        // data_size
+	SensorTypeDate                                            // date
+	SensorTypeDistance                                        // distance
+	SensorTypeDuration                                        // duration
+	SensorTypeEnergy                                          // energy
+	SensorTypeEnergyDistance                                  // energy_distance
+	SensorTypeEnergyStorage                                   // energy_storage
+	SensorTypeEnum                                            // enum
+	SensorTypeFrequency                                       // frequency
+	SensorTypeGas                                             // gas
+	SensorTypeHumidity                                        // humidity
+	SensorTypeIlluminance                                     // illuminance
+	SensorTypeIrradiance                                      // irradiance
+	SensorTypeMoisture                                        // moisture
+	SensorTypeMonetary                                        // monetary
+	SensorTypeNitrogenDioxide                                 // nitrogen_dioxide
+	SensorTypeNitrogenMonoxide                                // nitrogen_monoxide
+	SensorTypeNitrousOxide                                    // nitrous_oxide
+	SensorTypeOzone                                           // ozone
+	SensorTypePH                                              // ph
+	SensorTypePM1                                             // pm1
+	SensorTypePM25                                            // pm25
+	SensorTypePM10                                            // pm10
+	SensorTypePowerFactor                                     // power_factor
+	SensorTypePower                                           // power
+	SensorTypePrecipitation                                   // precipitation
+	SensorTypePrecipitationIntensity                          // precipitation_intensity
+	SensorTypePressure                                        // pressure
+	SensorTypeReactivePower                                   // reactive_power
+	SensorTypeSignalStrength                                  // signal_strength
+	SensorTypeSoundPressure                                   // sound_pressure
+	SensorTypeSpeed                                           // speed
+	SensorTypeSulphurDioxide                                  // sulphur_dioxide
+	SensorTypeTemperature                                     // temperature
+	SensorTypeTimestamp                                       // timestamp
+	SensorTypeVolatileOrganicCompounds                        // volatile_organic_compounds
+	SensorTypeVolatileOrganicCompoundsParts                   // volatile_organic_compounds_parts
+	SensorTypeVoltage                                         // voltage
+	SensorTypeVolume                                          // volume
+	SensorTypeVolumeFlowRate                                  // volume_flow_rate
+	SensorTypeVolumeStorage                                   // volume_storage
+	SensorTypeWater                                           // water
+	SensorTypeWeight                                          // weight
+	SensorTypeWindDirection                                   // wind_direction
+	SensorTypeWindSpeed                                       // wind_speed
+)
+
+// SensorType is the device class of a sensor (or number), which defines how it
+// is displayed in Home Assistant and the units it can have. See also:
+// https://www.home-assistant.io/integrations/sensor/#device-class
+type SensorType int
+
+const (
+	BinarySensorTypeNone            BinarySensorType = iota //
+	BinarySensorTypeBattery                                 // battery
+	BinarySensorTypeBatteryCharging                         // battery_charging
+	BinarySensorTypeCarbonMonoxide                          // carbon_monoxide
+	BinarySensorTypeCold                                    // cold
+	BinarySensorTypeConnectivity                            // connectivity
+	BinarySensorTypeDoor                                    // door
+	BinarySensorTypeGarageDoor                              // garage_door
+	BinarySensorTypeGas                                     // gas
+	BinarySensorTypeHeat                                    // heat
+	BinarySensorTypeLight                                   // light
+	BinarySensorTypeLock                                    // lock
+	BinarySensorTypeMoisture                                // moisture
+	BinarySensorTypeMotion                                  // motion
+	BinarySensorTypeMoving                                  // moving
+	BinarySensorTypeOccupancy                               // occupancy
+	BinarySensorTypeOpening                                 // opening
+	BinarySensorTypePlug                                    // plug
+	BinarySensorTypePower                                   // power
+	BinarySensorTypePresence                                // presence
+	BinarySensorTypeProblem                                 // problem
+	BinarySensorTypeRunning                                 // running
+	BinarySensorTypeSafety                                  // safety
+	BinarySensorTypeSmoke                                   // smoke
+	BinarySensorTypeSound                                   // sound
+	BinarySensorTypeTamper                                  // tamper
+	BinarySensorTypeUpdate                                  // update
+	BinarySensorTypeVibration                               // vibration
+	BinarySensorTypeWindow                                  // window
+)
+
+// BinarySensorType is the device class of a binary sensor, which defines how
+// it is displayed in Home Assistant. See also:
+// https://www.home-assistant.io/integrations/binary_sensor/#device-class
+type BinarySensorType int
+
+// sensorUnits are the units that Home Assistant accepts for each sensor device
+// class. An empty string means the device class has no units. Device classes
+// that are not listed accept any units (monetary), or none at all (date, enum,
+// timestamp).
+var sensorUnits = map[SensorType][]string{
+	SensorTypeApparentPower:                 {"mVA", "VA", "kVA"},
+	SensorTypeAQI:                           {""},
+	SensorTypeAbsoluteHumidity:              {"g/m³", "mg/m³"},
+	SensorTypeArea:                          {"m²", "cm²", "km²", "mm²", "in²", "ft²", "yd²", "mi²", "ac", "ha"},
+	SensorTypeAtmosphericPressure:           {"cbar", "bar", "hPa", "mmHg", "inHg", "kPa", "mbar", "Pa", "psi"},
+	SensorTypeBattery:                       {"%"},
+	SensorTypeBloodGlucoseConcentration:     {"mg/dL", "mmol/L"},
+	SensorTypeCarbonDioxide:                 {"ppm"},
+	SensorTypeCarbonMonoxide:                {"ppm"},
+	SensorTypeConductivity:                  {"S/cm", "mS/cm", "µS/cm"},
+	SensorTypeCurrent:                       {"A", "mA"},
+	SensorTypeDataRate:                      {"bit/s", "kbit/s", "Mbit/s", "Gbit/s", "B/s", "kB/s", "MB/s", "GB/s", "KiB/s", "MiB/s", "GiB/s"},
+	SensorTypeDataSize:                      {"bit", "kbit", "Mbit", "Gbit", "B", "kB", "MB", "GB", "TB", "PB", "EB", "ZB", "YB", "KiB", "MiB", "GiB", "TiB", "PiB", "EiB", "ZiB", "YiB"},
+	SensorTypeDistance:                      {"km", "m", "cm", "mm", "mi", "nmi", "yd", "in", "ft"},
+	SensorTypeDuration:                      {"d", "h", "min", "s", "ms"},
+	SensorTypeEnergy:                        {"J", "kJ", "MJ", "GJ", "mWh", "Wh", "kWh", "MWh", "GWh", "TWh", "cal", "kcal", "Mcal", "Gcal"},
+	SensorTypeEnergyDistance:                {"kWh/100km", "mi/kWh", "km/kWh"},
+	SensorTypeEnergyStorage:                 {"J", "kJ", "MJ", "GJ", "mWh", "Wh", "kWh", "MWh", "GWh", "TWh", "cal", "kcal", "Mcal", "Gcal"},
+	SensorTypeFrequency:                     {"Hz", "kHz", "MHz", "GHz"},
+	SensorTypeGas:                           {"m³", "ft³", "CCF", "L"},
+	SensorTypeHumidity:                      {"%"},
+	SensorTypeIlluminance:                   {"lx"},
+	SensorTypeIrradiance:                    {"W/m²", "BTU/(h⋅ft²)"},
+	SensorTypeMoisture:                      {"%"},
+	SensorTypeNitrogenDioxide:               {"µg/m³"},
+	SensorTypeNitrogenMonoxide:              {"µg/m³"},
+	SensorTypeNitrousOxide:                  {"µg/m³"},
+	SensorTypeOzone:                         {"µg/m³"},
+	SensorTypePH:                            {""},
+	SensorTypePM1:                           {"µg/m³"},
+	SensorTypePM25:                          {"µg/m³"},
+	SensorTypePM10:                          {"µg/m³"},
+	SensorTypePowerFactor:                   {"", "%"},
+	SensorTypePower:                         {"mW", "W", "kW", "MW", "GW", "TW"},
+	SensorTypePrecipitation:                 {"cm", "in", "mm"},
+	SensorTypePrecipitationIntensity:        {"in/d", "in/h", "mm/d", "mm/h"},
+	SensorTypePressure:                      {"Pa", "kPa", "hPa", "bar", "cbar", "mbar", "mmHg", "inHg", "psi"},
+	SensorTypeReactivePower:                 {"var", "kvar"},
+	SensorTypeSignalStrength:                {"dB", "dBm"},
+	SensorTypeSoundPressure:                 {"dB", "dBA"},
+	SensorTypeSpeed:                         {"ft/s", "in/d", "in/h", "in/s", "km/h", "kn", "m/s", "mph", "mm/d", "mm/s"},
+	SensorTypeSulphurDioxide:                {"µg/m³"},
+	SensorTypeTemperature:                   {"°C", "°F", "K"},
+	SensorTypeVolatileOrganicCompounds:      {"µg/m³", "mg/m³"},
+	SensorTypeVolatileOrganicCompoundsParts: {"ppm", "ppb"},
+	SensorTypeVoltage:                       {"V", "mV", "µV", "kV", "MV"},
+	SensorTypeVolume:                        {"L", "mL", "gal", "fl. oz.", "m³", "ft³", "CCF", "MCF"},
+	SensorTypeVolumeFlowRate:                {"m³/h", "m³/s", "ft³/min", "L/h", "L/min", "L/s", "gal/min", "mL/s"},
+	SensorTypeVolumeStorage:                 {"L", "mL", "gal", "fl. oz.", "m³", "ft³", "CCF", "MCF"},
+	SensorTypeWater:                         {"L", "gal", "m³", "ft³", "CCF", "MCF"},
+	SensorTypeWeight:                        {"kg", "g", "mg", "µg", "oz", "lb", "st"},
+	SensorTypeWindDirection:                 {"°"},
+	SensorTypeWindSpeed:                     {"ft/s", "km/h", "kn", "m/s", "mph"},
+}
+
+// sensorStateClasses are the state classes that Home Assistant accepts for
+// device classes that don't support all of them.
+var sensorStateClasses = map[SensorType][]string{
+	SensorTypeEnergy:        {stateClassTotal, stateClassTotalIncreasing},
+	SensorTypeEnergyStorage: {stateClassMeasurement},
+	SensorTypeGas:           {stateClassTotal, stateClassTotalIncreasing},
+	SensorTypeMonetary:      {stateClassTotal},
+	SensorTypeVolume:        {stateClassTotal, stateClassTotalIncreasing},
+	SensorTypeVolumeStorage: {stateClassMeasurement},
+	SensorTypeWater:         {stateClassTotal, stateClassTotalIncreasing},
+}
+
+// Units returns the units that Home Assistant accepts for a sensor of this
+// device class. An empty string means the sensor can have no units. If nil,
+// there are no restrictions on the units (or for non-numeric device classes,
+// the sensor cannot have units).
+func (t SensorType) Units() []string {
+	return slices.Clone(sensorUnits[t])
+}
+
+// numeric returns whether a sensor of this device class has a numeric state.
+func (t SensorType) numeric() bool {
+	switch t {
+	case SensorTypeDate, SensorTypeEnum, SensorTypeTimestamp:
+		return false
+	default:
+		return true
+	}
+}
+
+// parseSensorType returns the SensorType with the given device class, and
+// whether there is one.
+func parseSensorType(class string) (SensorType, bool) {
+	for t := SensorTypeApparentPower; t <= SensorTypeWindSpeed; t++ {
+		if t.String() == class {
+			return t, true
+		}
+	}
+
+	return SensorTypeNone, false
+}
+
+// parseBinarySensorType returns the BinarySensorType with the given device
+// class, and whether there is one.
+func parseBinarySensorType(class string) (BinarySensorType, bool) {
+	for t := BinarySensorTypeBattery; t <= BinarySensorTypeWindow; t++ {
+		if t.String() == class {
+			return t, true
+		}
+	}
+
+	return BinarySensorTypeNone, false
+}
+
+// SensorDeviceClass configures the device class of a sensor or number entity.
+// See also SensorType.
+func SensorDeviceClass(sensorType SensorType) StateOption {
+	return DeviceClass(sensorType.String())
+}
+
+// BinarySensorDeviceClass configures the device class of a binary sensor
+// entity.
+func BinarySensorDeviceClass(binarySensorType BinarySensorType) StateOption {
+	return DeviceClass(binarySensorType.String())
+}
+
 // SensorEntity represents an entity which has some kind of value. For more
 // details, see https://www.home-assistant.io/integrations/sensor.mqtt/
 type SensorEntity struct {
 	*EntityAttributes
 	*EntityState
 	*EntityDetails
+	*EntityAvailability
 	LastResetValueTemplate string `json:"last_reset_value_template,omitempty"`
 	entityType             EntityType
 	StateExpiry            int  `json:"expire_after,omitempty" validate:"omitempty,gte=0"`
@@ -71,6 +303,21 @@ func (e *SensorEntity) WithAttributes(options ...AttributeOption) *SensorEntity
 	return e
 }
 
+// WithAvailability configures the topics on which the availability of the
+// entity is reported.
+func (e *SensorEntity) WithAvailability(options ...AvailabilityOption) *SensorEntity {
+	e.EntityAvailability = WithAvailabilityOptions(options...)
+
+	return e
+}
+
+// MarshalState will generate an *mqtt.Msg for the state of the sensor. If the
+// sensor has forced updates, the state is marshaled even if it is only to be
+// published on change and has not changed.
+func (e *SensorEntity) MarshalState(args ...any) (*mqttapi.Msg, error) {
+	return e.marshalState(!e.ForceUpdate, args...)
+}
+
 func (e *SensorEntity) MarshalConfig() (*mqttapi.Msg, error) {
 	var (
 		cfg []byte
@@ -81,15 +328,90 @@ func (e *SensorEntity) MarshalConfig() (*mqttapi.Msg, error) {
 		return nil, fmt.Errorf("entity config is invalid: %w", err)
 	}
 
-	configTopic := generateTopic("config", e.EntityDetails)
+	configTopic := discoveryTopic(e.EntityDetails)
 
-	if cfg, err = json.Marshal(e); err != nil {
+	if cfg, err = marshalConfig(e, e.EntityDetails); err != nil {
 		return nil, fmt.Errorf("marshal config: %w", err)
 	}
 
 	return mqttapi.NewMsg(configTopic, cfg), nil
 }
 
+// validate checks the device class, units and state class of the sensor are a
+// combination that Home Assistant accepts.
+func (e *SensorEntity) validate() error {
+	var errs error
+
+	state := e.EntityState
+	if state == nil {
+		state = &EntityState{}
+	}
+
+	if e.LastResetValueTemplate != "" && state.StateClass != stateClassTotal {
+		errs = errors.Join(errs, errors.New("last_reset_value_template requires state class total"))
+	}
+
+	if e.entityType == BinarySensor {
+		return errors.Join(errs, state.validateBinarySensor())
+	}
+
+	return errors.Join(errs, state.validateSensor(e.entityType))
+}
+
+// validateSensor checks the state of a sensor (or number) has a valid device
+// class, units for that device class and a state class that the device class
+// supports.
+func (e *EntityState) validateSensor(entityType EntityType) error {
+	var errs error
+
+	sensorType, known := parseSensorType(e.DeviceClass)
+
+	switch {
+	case e.DeviceClass == "":
+	case !known, entityType != Sensor && !sensorType.numeric():
+		errs = errors.Join(errs, fmt.Errorf("device class %q is not valid for a %s", e.DeviceClass, entityType))
+	case !sensorType.numeric():
+		if e.UnitOfMeasurement != "" {
+			errs = errors.Join(errs, fmt.Errorf("device class %s cannot have units", sensorType))
+		}
+
+		if e.StateClass != "" {
+			errs = errors.Join(errs, fmt.Errorf("state class %s cannot be used with non-numeric device class %s",
+				e.StateClass, sensorType))
+		}
+	default:
+		if units, ok := sensorUnits[sensorType]; ok && e.UnitOfMeasurement != "" && !slices.Contains(units, e.UnitOfMeasurement) {
+			errs = errors.Join(errs, fmt.Errorf("units %q are not valid for device class %s", e.UnitOfMeasurement, sensorType))
+		}
+
+		if stateClasses, ok := sensorStateClasses[sensorType]; ok && e.StateClass != "" && !slices.Contains(stateClasses, e.StateClass) {
+			errs = errors.Join(errs, fmt.Errorf("state class %s is not valid for device class %s", e.StateClass, sensorType))
+		}
+	}
+
+	if e.StateClass != "" && entityType != Sensor {
+		errs = errors.Join(errs, fmt.Errorf("state class is not valid for a %s", entityType))
+	}
+
+	return errs
+}
+
+// validateBinarySensor checks the state of a binary sensor has a valid device
+// class and no numeric options.
+func (e *EntityState) validateBinarySensor() error {
+	var errs error
+
+	if _, known := parseBinarySensorType(e.DeviceClass); e.DeviceClass != "" && !known {
+		errs = errors.Join(errs, fmt.Errorf("device class %q is not valid for a binary_sensor", e.DeviceClass))
+	}
+
+	if e.UnitOfMeasurement != "" || e.StateClass != "" || e.SuggestedPrecision != 0 {
+		errs = errors.Join(errs, errors.New("units, state class and suggested precision are not valid for a binary_sensor"))
+	}
+
+	return errs
+}
+
 func NewSensorEntity() *SensorEntity {
 	entity := &SensorEntity{}
 	entity.entityType = Sensor
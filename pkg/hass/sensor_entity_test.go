@@ -0,0 +1,129 @@
+// Copyright (c) 2024 Joshua Rich <joshua.rich@gmail.com>
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package hass
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestSensorEntityDeviceClassConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		entity      *SensorEntity
+		deviceClass string
+	}{
+		{
+			name:        "sensor",
+			entity:      NewSensorEntity(),
+			deviceClass: "temperature",
+		},
+		{
+			name:        "binary sensor",
+			entity:      NewBinarySensorEntity(),
+			deviceClass: "door",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entity := tt.entity.
+				WithDetails(App("app"), Name("Test"), ID("test")).
+				WithState(DeviceClass(tt.deviceClass))
+
+			msg, err := entity.MarshalConfig()
+			if err != nil {
+				t.Fatalf("MarshalConfig() error = %v", err)
+			}
+
+			var config map[string]any
+			if err := json.Unmarshal(msg.Message, &config); err != nil {
+				t.Fatalf("could not unmarshal config: %v", err)
+			}
+
+			if got := config["device_class"]; got != tt.deviceClass {
+				t.Errorf("MarshalConfig() device_class = %v, want %v", got, tt.deviceClass)
+			}
+		})
+	}
+}
+
+func TestSensorEntityValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		entity  *SensorEntity
+		options []StateOption
+		wantErr bool
+	}{
+		{
+			name:    "valid units",
+			entity:  NewSensorEntity(),
+			options: []StateOption{SensorDeviceClass(SensorTypeTemperature), Units("°C"), StateClassMeasurement()},
+		},
+		{
+			name:    "invalid units",
+			entity:  NewSensorEntity(),
+			options: []StateOption{SensorDeviceClass(SensorTypeTemperature), Units("kWh")},
+			wantErr: true,
+		},
+		{
+			name:    "valid state class",
+			entity:  NewSensorEntity(),
+			options: []StateOption{SensorDeviceClass(SensorTypeEnergy), Units("kWh"), StateClassTotalIncreasing()},
+		},
+		{
+			name:    "invalid state class",
+			entity:  NewSensorEntity(),
+			options: []StateOption{SensorDeviceClass(SensorTypeEnergy), Units("kWh"), StateClassMeasurement()},
+			wantErr: true,
+		},
+		{
+			name:    "units on non-numeric device class",
+			entity:  NewSensorEntity(),
+			options: []StateOption{SensorDeviceClass(SensorTypeEnum), Units("°C")},
+			wantErr: true,
+		},
+		{
+			name:    "state class on non-numeric device class",
+			entity:  NewSensorEntity(),
+			options: []StateOption{SensorDeviceClass(SensorTypeTimestamp), StateClassMeasurement()},
+			wantErr: true,
+		},
+		{
+			name:    "unknown device class",
+			entity:  NewSensorEntity(),
+			options: []StateOption{DeviceClass("device_lass")},
+			wantErr: true,
+		},
+		{
+			name:    "valid binary sensor",
+			entity:  NewBinarySensorEntity(),
+			options: []StateOption{BinarySensorDeviceClass(BinarySensorTypeDoor)},
+		},
+		{
+			name:    "sensor device class on binary sensor",
+			entity:  NewBinarySensorEntity(),
+			options: []StateOption{DeviceClass("temperature")},
+			wantErr: true,
+		},
+		{
+			name:    "units on binary sensor",
+			entity:  NewBinarySensorEntity(),
+			options: []StateOption{BinarySensorDeviceClass(BinarySensorTypeDoor), Units("°C")},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entity := tt.entity.
+				WithDetails(App("app"), Name("Test"), ID("test")).
+				WithState(tt.options...)
+
+			if err := entity.validate(); (err != nil) != tt.wantErr {
+				t.Errorf("validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
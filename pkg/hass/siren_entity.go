@@ -0,0 +1,207 @@
+// Copyright (c) 2024 Joshua Rich <joshua.rich@gmail.com>
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package hass
+
+import (
+	"encoding/json"
+	"fmt"
+
+	mqttapi "github.com/joshuar/go-hass-anything/v12/pkg/mqtt"
+)
+
+// SirenCommand represents a command received for a siren entity.
+type SirenCommand struct {
+	// VolumeLevel is the requested volume, between 0 and 1. It is only set if
+	// the siren supports setting the volume and a volume was requested.
+	VolumeLevel *float64 `json:"volume_level,omitempty"`
+	// Duration is the requested duration in seconds. It is only set if the
+	// siren supports a duration and a duration was requested.
+	Duration *int `json:"duration,omitempty"`
+	// State is the raw on/off payload of the command.
+	State string `json:"state"`
+	// Tone is the requested tone. It is only set if the siren has a list of
+	// available tones and a tone was requested.
+	Tone string `json:"tone,omitempty"`
+	// On indicates whether the siren should be turned on (true) or off
+	// (false).
+	On bool `json:"-"`
+}
+
+// SirenEntity represents an entity that controls a siren. Sirens can be turned
+// on and off and optionally support choosing a tone, volume and duration when
+// turned on. For more details see
+// https://www.home-assistant.io/integrations/siren.mqtt/
+type SirenEntity struct {
+	*EntityDetails
+	*EntityAvailability
+	*EntityCommand
+	*EntityState
+	*EntityAttributes
+	StateValueTemplate string   `json:"state_value_template,omitempty"`
+	CommandOffTemplate string   `json:"command_off_template,omitempty"`
+	PayloadOn          string   `json:"payload_on,omitempty"`
+	PayloadOff         string   `json:"payload_off,omitempty"`
+	StateOn            string   `json:"state_on,omitempty"`
+	StateOff           string   `json:"state_off,omitempty"`
+	AvailableTones     []string `json:"available_tones,omitempty" validate:"omitempty,unique"`
+	SupportDuration    bool     `json:"support_duration"`
+	SupportVolumeSet   bool     `json:"support_volume_set"`
+	Optimistic         bool     `json:"optimistic,omitempty"`
+}
+
+// OptimisticMode ensures the siren works in optimistic mode.
+func (e *SirenEntity) OptimisticMode() *SirenEntity {
+	e.Optimistic = true
+
+	return e
+}
+
+// WithTones sets the list of tones the siren supports. When set, a tone can be
+// chosen in Home Assistant when the siren is turned on.
+func (e *SirenEntity) WithTones(tones ...string) *SirenEntity {
+	e.AvailableTones = tones
+
+	return e
+}
+
+// WithVolumeSupport indicates the siren supports setting a volume when turned
+// on.
+func (e *SirenEntity) WithVolumeSupport() *SirenEntity {
+	e.SupportVolumeSet = true
+
+	return e
+}
+
+// WithDurationSupport indicates the siren supports setting a duration when
+// turned on.
+func (e *SirenEntity) WithDurationSupport() *SirenEntity {
+	e.SupportDuration = true
+
+	return e
+}
+
+// WithOnPayload sets the payload that represents the on state. Defaults to ON.
+func (e *SirenEntity) WithOnPayload(payload string) *SirenEntity {
+	e.PayloadOn = payload
+
+	return e
+}
+
+// WithOffPayload sets the payload that represents the off state. Defaults to
+// OFF.
+func (e *SirenEntity) WithOffPayload(payload string) *SirenEntity {
+	e.PayloadOff = payload
+
+	return e
+}
+
+// WithCommandOffTemplate sets a template used to generate the payload sent to
+// the command topic when the siren is turned off.
+func (e *SirenEntity) WithCommandOffTemplate(template string) *SirenEntity {
+	e.CommandOffTemplate = template
+
+	return e
+}
+
+func (e *SirenEntity) WithDetails(options ...DetailsOption) *SirenEntity {
+	e.EntityDetails = WithDetails(Siren, options...)
+
+	return e
+}
+
+// WithState configures the state used to report whether the siren is on or
+// off.
+func (e *SirenEntity) WithState(options ...StateOption) *SirenEntity {
+	e.EntityState = WithStateOptions(options...)
+	e.StateTopic = generateTopic("state", e.EntityDetails)
+	// Sirens use state_value_template rather than value_template.
+	e.StateValueTemplate = e.ValueTemplate
+	e.ValueTemplate = ""
+
+	return e
+}
+
+// WithCommand configures the command used to turn the siren on or off.
+func (e *SirenEntity) WithCommand(options ...CommandOption) *SirenEntity {
+	e.EntityCommand = WithCommandOptions(options...)
+	e.CommandTopic = generateTopic("set", e.EntityDetails)
+
+	return e
+}
+
+// WithCommandHandler configures the command of the siren to pass each command
+// received, decoded as a SirenCommand, to the given handler. Commands that are
+// neither the on or off payload are logged and ignored.
+func (e *SirenEntity) WithCommandHandler(handler func(cmd *SirenCommand), options ...CommandOption) *SirenEntity {
+	options = append(options, CommandCallback(decodeCommand(e.decodeCommand, handler)))
+
+	return e.WithCommand(options...)
+}
+
+func (e *SirenEntity) WithAttributes(options ...AttributeOption) *SirenEntity {
+	e.EntityAttributes = WithAttributesOptions(options...)
+	e.AttributesTopic = generateTopic("attributes", e.EntityDetails)
+
+	return e
+}
+
+// WithAvailability configures the topics on which the availability of the
+// entity is reported.
+func (e *SirenEntity) WithAvailability(options ...AvailabilityOption) *SirenEntity {
+	e.EntityAvailability = WithAvailabilityOptions(options...)
+
+	return e
+}
+
+func (e *SirenEntity) MarshalConfig() (*mqttapi.Msg, error) {
+	var (
+		cfg []byte
+		err error
+	)
+
+	if err = validateEntity(e); err != nil {
+		return nil, fmt.Errorf("entity config is invalid: %w", err)
+	}
+
+	configTopic := discoveryTopic(e.EntityDetails)
+
+	if cfg, err = marshalConfig(e, e.EntityDetails); err != nil {
+		return nil, fmt.Errorf("marshal config: %w", err)
+	}
+
+	return mqttapi.NewMsg(configTopic, cfg), nil
+}
+
+// decodeCommand converts a command payload into a SirenCommand. When turned on
+// with a tone, volume or duration, Home Assistant sends a JSON object
+// containing these along with the state. Otherwise, the payload is just the on
+// or off payload.
+func (e *SirenEntity) decodeCommand(payload []byte) (*SirenCommand, error) {
+	command := &SirenCommand{}
+
+	if err := json.Unmarshal(payload, command); err != nil || command.State == "" {
+		command = &SirenCommand{State: string(payload)}
+	}
+
+	switch command.State {
+	case payloadOrDefault(e.PayloadOn, "ON"):
+		command.On = true
+	case payloadOrDefault(e.PayloadOff, "OFF"):
+		command.On = false
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownPayload, command.State)
+	}
+
+	return command, nil
+}
+
+func (e *SirenEntity) validate() error {
+	return validateNoSensorStateOptions(e.EntityState, Siren)
+}
+
+func NewSirenEntity() *SirenEntity {
+	return &SirenEntity{}
+}
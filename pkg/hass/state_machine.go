@@ -0,0 +1,77 @@
+// Copyright (c) 2024 Joshua Rich <joshua.rich@gmail.com>
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package hass
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+
+	mqttapi "github.com/joshuar/go-hass-anything/v12/pkg/mqtt"
+)
+
+// StateMachine tracks the current activity state of an entity, such as a
+// vacuum or lawn mower, and only allows transitions between states that are
+// valid for that entity. Each valid transition generates the state message for
+// the new state. A StateMachine is safe for concurrent use.
+type StateMachine[S comparable] struct {
+	marshal     func(state S) (*mqttapi.Msg, error)
+	transitions map[S][]S
+	current     S
+	mu          sync.Mutex
+}
+
+// newStateMachine creates a new StateMachine starting in the initial state.
+// The transitions map each state to the states it can transition to. The
+// marshal function generates the state message for a state.
+//
+//nolint:lll
+func newStateMachine[S comparable](initial S, transitions map[S][]S, marshal func(state S) (*mqttapi.Msg, error)) *StateMachine[S] {
+	return &StateMachine[S]{
+		current:     initial,
+		marshal:     marshal,
+		transitions: transitions,
+	}
+}
+
+// Current returns the current state.
+func (m *StateMachine[S]) Current() S {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.current
+}
+
+// Transition moves to the given state and returns an *mqtt.Msg that can be
+// used to publish the new state. If the transition is not valid from the
+// current state, the state is left unchanged and an error is returned.
+// Transitioning to the current state is always valid and will regenerate the
+// state message.
+func (m *StateMachine[S]) Transition(state S) (*mqttapi.Msg, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if state != m.current && !slices.Contains(m.transitions[m.current], state) {
+		return nil, fmt.Errorf("%w: %v to %v", ErrInvalidTransition, m.current, state)
+	}
+
+	msg, err := m.marshal(state)
+	if err != nil {
+		return nil, err
+	}
+
+	m.current = state
+
+	return msg, nil
+}
+
+// State returns an *mqtt.Msg that can be used to publish the current state.
+func (m *StateMachine[S]) State() (*mqttapi.Msg, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.marshal(m.current)
+}
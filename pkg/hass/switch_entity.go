@@ -3,19 +3,36 @@
 // This software is released under the MIT License.
 // https://opensource.org/licenses/MIT
 
+//go:generate go run golang.org/x/tools/cmd/stringer -type=SwitchType -output switch_entity_generated.go -linecomment
 package hass
 
 import (
-	"encoding/json"
 	"fmt"
 
 	mqttapi "github.com/joshuar/go-hass-anything/v12/pkg/mqtt"
 )
 
+const (
+	SwitchTypeNone   SwitchType = iota //
+	SwitchTypeOutlet                   // outlet
+	SwitchTypeSwitch                   // switch
+)
+
+// SwitchType is the device class of a switch, which defines how it is
+// displayed in Home Assistant. See also:
+// https://www.home-assistant.io/integrations/switch/#device-class
+type SwitchType int
+
+// SwitchDeviceClass configures the device class of a switch entity.
+func SwitchDeviceClass(switchType SwitchType) StateOption {
+	return DeviceClass(switchType.String())
+}
+
 // SwitchEntity represents an entity that can be turned on or off. For more
 // details see https://www.home-assistant.io/integrations/switch.mqtt/
 type SwitchEntity struct {
 	*EntityDetails
+	*EntityAvailability
 	*EntityCommand
 	*EntityState
 	*EntityAttributes
@@ -95,6 +112,15 @@ func (e *SwitchEntity) WithCommand(options ...CommandOption) *SwitchEntity {
 	return e
 }
 
+// WithCommandHandler configures the command of the switch to pass each command
+// received to the given handler, as true for the on payload and false for the
+// off payload. Any other payload is logged and ignored.
+func (e *SwitchEntity) WithCommandHandler(handler func(on bool), options ...CommandOption) *SwitchEntity {
+	options = append(options, CommandCallback(decodeCommand(e.decodeCommand, handler)))
+
+	return e.WithCommand(options...)
+}
+
 func (e *SwitchEntity) WithAttributes(options ...AttributeOption) *SwitchEntity {
 	e.EntityAttributes = WithAttributesOptions(options...)
 	e.AttributesTopic = generateTopic("attributes", e.EntityDetails)
@@ -102,6 +128,14 @@ func (e *SwitchEntity) WithAttributes(options ...AttributeOption) *SwitchEntity
 	return e
 }
 
+// WithAvailability configures the topics on which the availability of the
+// entity is reported.
+func (e *SwitchEntity) WithAvailability(options ...AvailabilityOption) *SwitchEntity {
+	e.EntityAvailability = WithAvailabilityOptions(options...)
+
+	return e
+}
+
 func (e *SwitchEntity) MarshalConfig() (*mqttapi.Msg, error) {
 	var (
 		cfg []byte
@@ -112,15 +146,43 @@ func (e *SwitchEntity) MarshalConfig() (*mqttapi.Msg, error) {
 		return nil, fmt.Errorf("entity config is invalid: %w", err)
 	}
 
-	configTopic := generateTopic("config", e.EntityDetails)
+	configTopic := discoveryTopic(e.EntityDetails)
 
-	if cfg, err = json.Marshal(e); err != nil {
+	if cfg, err = marshalConfig(e, e.EntityDetails); err != nil {
 		return nil, fmt.Errorf("marshal config: %w", err)
 	}
 
 	return mqttapi.NewMsg(configTopic, cfg), nil
 }
 
+// decodeCommand converts a command payload into whether the switch should be
+// turned on.
+func (e *SwitchEntity) decodeCommand(payload []byte) (bool, error) {
+	switch string(payload) {
+	case payloadOrDefault(e.PayloadOn, StatePayloadOn):
+		return true, nil
+	case payloadOrDefault(e.PayloadOff, StatePayloadOff):
+		return false, nil
+	default:
+		return false, fmt.Errorf("%w: %s", ErrUnknownPayload, string(payload))
+	}
+}
+
+// validate checks the device class of the switch is one that Home Assistant
+// accepts.
+func (e *SwitchEntity) validate() error {
+	if e.EntityState == nil {
+		return nil
+	}
+
+	switch e.DeviceClass {
+	case SwitchTypeNone.String(), SwitchTypeOutlet.String(), SwitchTypeSwitch.String():
+		return nil
+	default:
+		return fmt.Errorf("device class %q is not valid for a switch", e.DeviceClass)
+	}
+}
+
 func NewSwitchEntity() *SwitchEntity {
 	return &SwitchEntity{}
 }
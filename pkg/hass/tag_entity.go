@@ -0,0 +1,75 @@
+// Copyright (c) 2024 Joshua Rich <joshua.rich@gmail.com>
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package hass
+
+import (
+	"fmt"
+
+	mqttapi "github.com/joshuar/go-hass-anything/v12/pkg/mqtt"
+)
+
+// TagEntity represents a tag scanner, such as an NFC or RFID reader. Each
+// scanned tag ID is published to the topic of the scanner. Tag scanners must be
+// associated with a device (see DeviceInfo). For more details see
+// https://www.home-assistant.io/integrations/tag.mqtt/
+type TagEntity struct {
+	*EntityDetails
+	Topic         string `json:"topic" validate:"required"`
+	ValueTemplate string `json:"value_template,omitempty"`
+}
+
+func (e *TagEntity) WithDetails(options ...DetailsOption) *TagEntity {
+	e.EntityDetails = WithDetails(Tag, options...)
+
+	return e
+}
+
+// WithState configures the topic that scanned tag IDs are published to. It is
+// required. A value template can be provided if the tag ID needs to be
+// extracted from the published payload.
+func (e *TagEntity) WithState(options ...StateOption) *TagEntity {
+	e.Topic = generateTopic("state", e.EntityDetails)
+	e.ValueTemplate = WithStateOptions(options...).ValueTemplate
+
+	return e
+}
+
+// MarshalTagScanned will generate an *mqtt.Msg for the given tag ID, that can
+// be used to publish that the tag was scanned.
+func (e *TagEntity) MarshalTagScanned(tagID string) (*mqttapi.Msg, error) {
+	if e.Topic == "" {
+		return nil, fmt.Errorf("could not marshal state: %w", ErrNoStateTopic)
+	}
+
+	return mqttapi.NewMsg(e.Topic, []byte(tagID)), nil
+}
+
+func (e *TagEntity) MarshalConfig() (*mqttapi.Msg, error) {
+	var (
+		cfg []byte
+		err error
+	)
+
+	if e.EntityDetails == nil || e.Device == nil {
+		return nil, fmt.Errorf("entity config is invalid: %w", ErrNoDevice)
+	}
+
+	if err = validateEntity(e); err != nil {
+		return nil, fmt.Errorf("entity config is invalid: %w", err)
+	}
+
+	configTopic := discoveryTopic(e.EntityDetails)
+
+	if cfg, err = marshalConfig(e, e.EntityDetails); err != nil {
+		return nil, fmt.Errorf("marshal config: %w", err)
+	}
+
+	return mqttapi.NewMsg(configTopic, cfg), nil
+}
+
+func NewTagEntity() *TagEntity {
+	return &TagEntity{}
+}
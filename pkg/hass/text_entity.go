@@ -6,8 +6,9 @@
 package hass
 
 import (
-	"encoding/json"
 	"fmt"
+	"regexp"
+	"unicode/utf8"
 
 	mqttapi "github.com/joshuar/go-hass-anything/v12/pkg/mqtt"
 )
@@ -25,9 +26,11 @@ type TextEntityMode int
 // https://www.home-assistant.io/integrations/text.mqtt/
 type TextEntity struct {
 	*EntityDetails
+	*EntityAvailability
 	*EntityCommand
 	*EntityAttributes
 	*EntityState
+	pattern *regexp.Regexp
 	Mode    string `json:"mode,omitempty"`
 	Pattern string `json:"pattern,omitempty"`
 	Min     int    `json:"min,omitempty" validate:"min=0"`
@@ -61,9 +64,10 @@ func (e *TextEntity) WithMode(mode TextEntityMode) *TextEntity {
 }
 
 // WithPattern sets a valid regular expression the text being set or received
-// must match with.
+// must match with. An invalid pattern is reported when the config is marshaled.
 func (e *TextEntity) WithPattern(pattern string) *TextEntity {
 	e.Pattern = pattern
+	e.pattern, _ = compileTextPattern(pattern)
 
 	return e
 }
@@ -88,6 +92,16 @@ func (e *TextEntity) WithCommand(options ...CommandOption) *TextEntity {
 	return e
 }
 
+// WithCommandHandler configures the command of the text entity to pass each
+// text received to the given handler. Text that is shorter or longer than the
+// minimum or maximum size, or does not match the pattern (if set), is logged and
+// ignored.
+func (e *TextEntity) WithCommandHandler(handler func(text string), options ...CommandOption) *TextEntity {
+	options = append(options, CommandCallback(decodeCommand(e.decodeCommand, handler)))
+
+	return e.WithCommand(options...)
+}
+
 func (e *TextEntity) WithAttributes(options ...AttributeOption) *TextEntity {
 	e.EntityAttributes = WithAttributesOptions(options...)
 	e.AttributesTopic = generateTopic("attributes", e.EntityDetails)
@@ -95,6 +109,14 @@ func (e *TextEntity) WithAttributes(options ...AttributeOption) *TextEntity {
 	return e
 }
 
+// WithAvailability configures the topics on which the availability of the
+// entity is reported.
+func (e *TextEntity) WithAvailability(options ...AvailabilityOption) *TextEntity {
+	e.EntityAvailability = WithAvailabilityOptions(options...)
+
+	return e
+}
+
 func (e *TextEntity) MarshalConfig() (*mqttapi.Msg, error) {
 	var (
 		cfg []byte
@@ -105,15 +127,73 @@ func (e *TextEntity) MarshalConfig() (*mqttapi.Msg, error) {
 		return nil, fmt.Errorf("entity config is invalid: %w", err)
 	}
 
-	configTopic := generateTopic("config", e.EntityDetails)
+	configTopic := discoveryTopic(e.EntityDetails)
 
-	if cfg, err = json.Marshal(e); err != nil {
+	if cfg, err = marshalConfig(e, e.EntityDetails); err != nil {
 		return nil, fmt.Errorf("marshal config: %w", err)
 	}
 
 	return mqttapi.NewMsg(configTopic, cfg), nil
 }
 
+// decodeCommand checks a command payload against the minimum and maximum size
+// and pattern of the text entity.
+func (e *TextEntity) decodeCommand(payload []byte) (string, error) {
+	text := string(payload)
+	length := utf8.RuneCountInString(text)
+	// Home Assistant defaults to a maximum of 255 characters.
+	maxLength := e.Max
+	if maxLength == 0 {
+		maxLength = 255
+	}
+
+	if length < e.Min || length > maxLength {
+		return "", fmt.Errorf("%w: length %d is not between %d and %d", ErrInvalidValue, length, e.Min, maxLength)
+	}
+
+	if e.Pattern != "" {
+		if e.pattern == nil {
+			return "", fmt.Errorf("%w: pattern %s is not a valid regular expression", ErrInvalidValue, e.Pattern)
+		}
+
+		if !e.pattern.MatchString(text) {
+			return "", fmt.Errorf("%w: %q does not match pattern %s", ErrInvalidValue, text, e.Pattern)
+		}
+	}
+
+	return text, nil
+}
+
+// validate checks the pattern of the text entity is a valid regular expression.
+// A pattern set directly, rather than with WithPattern, is compiled here.
+func (e *TextEntity) validate() error {
+	if e.Pattern == "" {
+		e.pattern = nil
+
+		return nil
+	}
+
+	pattern, err := compileTextPattern(e.Pattern)
+	if err != nil {
+		return fmt.Errorf("pattern is not a valid regular expression: %w", err)
+	}
+
+	e.pattern = pattern
+
+	return nil
+}
+
+// compileTextPattern compiles the pattern of a text entity. Home Assistant only
+// requires the pattern to match at the start of the text.
+func compileTextPattern(pattern string) (*regexp.Regexp, error) {
+	compiled, err := regexp.Compile("^(?:" + pattern + ")")
+	if err != nil {
+		return nil, fmt.Errorf("compile pattern: %w", err)
+	}
+
+	return compiled, nil
+}
+
 func NewTextEntity() *TextEntity {
 	return &TextEntity{}
 }
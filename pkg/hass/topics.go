@@ -0,0 +1,85 @@
+// Copyright (c) 2024 Joshua Rich <joshua.rich@gmail.com>
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package hass
+
+import (
+	"strings"
+)
+
+// TopicStrategy controls the layout of the state, command, attributes and
+// other topics of an entity. The discovery (config) topic of an entity is not
+// affected and is always under HomeAssistantTopic, where Home Assistant expects
+// it.
+type TopicStrategy interface {
+	// EntityTopic returns the topic with the given name (i.e., "state", "set")
+	// for the entity of the given type, app and unique ID.
+	EntityTopic(entityType EntityType, app, id, name string) string
+}
+
+// TopicStrategyFunc is an adapter to allow the use of an ordinary function as a
+// TopicStrategy.
+type TopicStrategyFunc func(entityType EntityType, app, id, name string) string
+
+// EntityTopic calls f(entityType, app, id, name).
+func (f TopicStrategyFunc) EntityTopic(entityType EntityType, app, id, name string) string {
+	return f(entityType, app, id, name)
+}
+
+// DefaultTopics returns the TopicStrategy used when none is configured for an
+// entity. It places all topics under HomeAssistantTopic, as
+// <prefix>/<type>/<app>/<id>/<name>.
+func DefaultTopics() TopicStrategy {
+	return TopicStrategyFunc(func(entityType EntityType, app, id, name string) string {
+		return strings.Join([]string{HomeAssistantTopic, entityType.String(), app, id, name}, "/")
+	})
+}
+
+// BaseTopics returns a TopicStrategy that places all topics under the given
+// base topic, as <base>/<type>/<id>/<name>. For example, with a base of
+// "myhouse/garage", the state topic of a sensor with the ID "door" would be
+// "myhouse/garage/sensor/door/state".
+func BaseTopics(base string) TopicStrategy {
+	base = strings.TrimSuffix(base, "/")
+
+	return TopicStrategyFunc(func(entityType EntityType, _, id, name string) string {
+		return strings.Join([]string{base, entityType.String(), id, name}, "/")
+	})
+}
+
+// Topics configures the TopicStrategy used to generate the topics of the
+// entity. To use the same layout for all entities of an app or device, pass
+// the same option to each entity. The option must be passed to WithDetails
+// before any of the state, command or attributes of the entity are configured.
+func Topics(strategy TopicStrategy) DetailsOption {
+	return func(e *EntityDetails) *EntityDetails {
+		e.topics = strategy
+
+		return e
+	}
+}
+
+// generateTopic generates the topic with the given name for the entity, using
+// the TopicStrategy of the entity.
+func generateTopic(topicName string, details *EntityDetails) string {
+	topics := details.topics
+	if topics == nil {
+		topics = DefaultTopics()
+	}
+
+	return topics.EntityTopic(details.entityType, appID(details.app), details.UniqueID, topicName)
+}
+
+// discoveryTopic generates the topic on which the config of the entity is
+// published. This is always under HomeAssistantTopic, as per the Home Assistant
+// discovery topic format.
+func discoveryTopic(details *EntityDetails) string {
+	return strings.Join([]string{HomeAssistantTopic, details.entityType.String(), appID(details.app), details.UniqueID, "config"}, "/")
+}
+
+// appID formats the given app name to be appropriate for use in a topic.
+func appID(app string) string {
+	return strings.ToLower(strings.ReplaceAll(app, " ", "_"))
+}
@@ -0,0 +1,177 @@
+// Copyright (c) 2024 Joshua Rich <joshua.rich@gmail.com>
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package hass
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// StatePayloadNone is the state payload that resets an entity to an
+	// unknown state.
+	StatePayloadNone = "None"
+	// StatePayloadOn is the default state payload for a boolean true value.
+	StatePayloadOn = "ON"
+	// StatePayloadOff is the default state payload for a boolean false value.
+	StatePayloadOff = "OFF"
+)
+
+// StateValue represents the types of values that can be used as the value of a
+// TypedState.
+type StateValue interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 |
+		~float32 | ~float64 | ~bool | ~string | time.Time
+}
+
+// TypedState holds the current value of an entity state. Rather than a state
+// callback returning a raw payload, a TypedState can be used with
+// TypedStateCallback and the value of the state set as it changes. The value
+// is serialized as appropriate for its type:
+//
+//   - Numbers are sent as their decimal representation. NaN and infinite
+//     values are sent as None.
+//   - Booleans are sent as ON or OFF, which are the default payloads of binary
+//     sensors and switches. See WithPayloads to change these.
+//   - Times are sent in RFC 3339 format, as expected by timestamp sensors.
+//   - A value that is not set (or has been reset) is sent as None, which marks
+//     the state as unknown.
+//
+// A TypedState is safe for concurrent use.
+type TypedState[T StateValue] struct {
+	value      *T
+	payloadOn  string
+	payloadOff string
+	mu         sync.RWMutex
+}
+
+// WithPayloads sets the payloads sent for a boolean value. Defaults to ON and
+// OFF. It has no effect for other types.
+func (s *TypedState[T]) WithPayloads(on, off string) *TypedState[T] {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.payloadOn = on
+	s.payloadOff = off
+
+	return s
+}
+
+// Set sets the value of the state.
+func (s *TypedState[T]) Set(value T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.value = &value
+}
+
+// Reset clears the value of the state, such that the state will be unknown.
+func (s *TypedState[T]) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.value = nil
+}
+
+// Value returns the value of the state and whether it is set.
+func (s *TypedState[T]) Value() (T, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.value == nil {
+		var zero T
+
+		return zero, false
+	}
+
+	return *s.value, true
+}
+
+// MarshalText returns the state payload for the current value of the state.
+func (s *TypedState[T]) MarshalText() ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.value == nil {
+		return []byte(StatePayloadNone), nil
+	}
+
+	return marshalStateValue(*s.value,
+		payloadOrDefault(s.payloadOn, StatePayloadOn),
+		payloadOrDefault(s.payloadOff, StatePayloadOff))
+}
+
+// NewTypedState creates a new TypedState. The state will be unknown until a
+// value is set.
+func NewTypedState[T StateValue]() *TypedState[T] {
+	return &TypedState[T]{}
+}
+
+// TypedStateCallback configures the entity to use the current value of the
+// given TypedState as its state.
+func TypedStateCallback[T StateValue](state *TypedState[T]) StateOption {
+	return StateCallback(func(_ ...any) (json.RawMessage, error) {
+		return state.MarshalText()
+	})
+}
+
+// TypedAttributesCallback configures the entity to use the value returned by
+// the given function, marshaled as JSON, as its attributes.
+func TypedAttributesCallback[T any](attributes func() (T, error)) AttributeOption {
+	return AttributesCallback(func(_ ...any) (json.RawMessage, error) {
+		value, err := attributes()
+		if err != nil {
+			return nil, err
+		}
+
+		payload, err := json.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("could not marshal attributes: %w", err)
+		}
+
+		return payload, nil
+	})
+}
+
+// marshalStateValue returns the state payload for the given value.
+func marshalStateValue(value any, payloadOn, payloadOff string) ([]byte, error) {
+	if t, ok := value.(time.Time); ok {
+		return []byte(t.Format(time.RFC3339)), nil
+	}
+
+	v := reflect.ValueOf(value)
+
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return []byte(strconv.FormatInt(v.Int(), 10)), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return []byte(strconv.FormatUint(v.Uint(), 10)), nil
+	case reflect.Float32, reflect.Float64:
+		// Home Assistant cannot parse NaN or infinite values, so treat them as
+		// unknown.
+		if math.IsNaN(v.Float()) || math.IsInf(v.Float(), 0) {
+			return []byte(StatePayloadNone), nil
+		}
+
+		return []byte(strconv.FormatFloat(v.Float(), 'f', -1, v.Type().Bits())), nil
+	case reflect.Bool:
+		if v.Bool() {
+			return []byte(payloadOn), nil
+		}
+
+		return []byte(payloadOff), nil
+	case reflect.String:
+		return []byte(v.String()), nil
+	default:
+		return nil, fmt.Errorf("could not marshal state: unsupported type %T", value)
+	}
+}
@@ -0,0 +1,267 @@
+// Copyright (c) 2024 Joshua Rich <joshua.rich@gmail.com>
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+//go:generate go run golang.org/x/tools/cmd/stringer -type=UpdateType -output update_entity_generated.go -linecomment
+package hass
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sync/atomic"
+
+	"github.com/eclipse/paho.golang/paho"
+
+	mqttapi "github.com/joshuar/go-hass-anything/v12/pkg/mqtt"
+)
+
+const (
+	UpdateTypeNone     UpdateType = iota //
+	UpdateTypeFirmware                   // firmware
+)
+
+// UpdateType is the type of update, which defines how it is displayed in Home
+// Assistant.
+type UpdateType int
+
+// updatePayloadInstall is the payload used to start an install if no other
+// payload has been configured.
+const updatePayloadInstall = "INSTALL"
+
+// UpdateState represents the state of an update entity. Only the fields that
+// are set will be updated in Home Assistant, except for InProgress, which is
+// always sent.
+type UpdateState struct {
+	// UpdatePercentage is the progress of an install, as a percentage.
+	UpdatePercentage *float64 `json:"update_percentage,omitempty" validate:"omitempty,gte=0,lte=100"`
+	// InstalledVersion is the currently installed version.
+	InstalledVersion string `json:"installed_version,omitempty"`
+	// LatestVersion is the latest available version.
+	LatestVersion string `json:"latest_version,omitempty"`
+	// Title is the title of the software or firmware.
+	Title string `json:"title,omitempty"`
+	// ReleaseSummary is a summary of the latest release notes.
+	ReleaseSummary string `json:"release_summary,omitempty" validate:"omitempty,max=255"`
+	// ReleaseURL is a URL to the full release notes of the latest version.
+	ReleaseURL string `json:"release_url,omitempty" validate:"omitempty,url"`
+	// EntityPicture is a URL to a picture for the update.
+	EntityPicture string `json:"entity_picture,omitempty" validate:"omitempty,url"`
+	// InProgress indicates whether an install is in progress.
+	InProgress bool `json:"in_progress"`
+}
+
+// UpdateProgress is passed to the install handler of an update entity and can
+// be used to report the progress of the install back to Home Assistant. Each
+// report is sent as a message on the channel given to WithInstallHandler, which
+// would usually be the channel returned by the MsgCh method of an app.
+type UpdateProgress struct {
+	entity *UpdateEntity
+	msgCh  chan *mqttapi.Msg
+}
+
+// Report sends the given percentage as the progress of the install.
+func (p *UpdateProgress) Report(percentage float64) {
+	p.send(&UpdateState{InProgress: true, UpdatePercentage: &percentage})
+}
+
+// Installed reports that the install has finished and the given version is now
+// installed.
+func (p *UpdateProgress) Installed(version string) {
+	p.send(&UpdateState{InstalledVersion: version})
+}
+
+func (p *UpdateProgress) send(state *UpdateState) {
+	msg, err := p.entity.MarshalUpdateState(state)
+	if err != nil {
+		slog.Warn("Could not report update progress.",
+			slog.String("entity", p.entity.Name),
+			slog.Any("error", err))
+
+		return
+	}
+
+	p.msgCh <- msg
+}
+
+// UpdateEntity represents an entity that advertises updates to software or
+// firmware and, optionally, allows them to be installed. For more details see
+// https://www.home-assistant.io/integrations/update.mqtt/
+type UpdateEntity struct {
+	*EntityDetails
+	*EntityAvailability
+	*EntityCommand
+	*EntityState
+	*EntityAttributes
+	installing       atomic.Bool
+	UpdateType       string `json:"device_class,omitempty"`
+	PayloadInstall   string `json:"payload_install,omitempty"`
+	Title            string `json:"title,omitempty"`
+	ReleaseSummary   string `json:"release_summary,omitempty" validate:"omitempty,max=255"`
+	ReleaseURL       string `json:"release_url,omitempty" validate:"omitempty,url"`
+	DisplayPrecision int    `json:"display_precision,omitempty" validate:"omitempty,gte=0"`
+}
+
+// WithUpdateType sets the type of update, defining how it gets displayed in
+// Home Assistant. The DeviceClass state option cannot be used for updates.
+func (e *UpdateEntity) WithUpdateType(updateType UpdateType) *UpdateEntity {
+	e.UpdateType = updateType.String()
+
+	return e
+}
+
+// WithInstallPayload sets the payload sent to the command topic to start an
+// install. Defaults to INSTALL.
+func (e *UpdateEntity) WithInstallPayload(payload string) *UpdateEntity {
+	e.PayloadInstall = payload
+
+	return e
+}
+
+// WithReleaseInfo sets the title of the software or firmware, as well as a
+// summary of and URL to the release notes. These can also be reported as part
+// of the state, see UpdateState.
+func (e *UpdateEntity) WithReleaseInfo(title, summary, url string) *UpdateEntity {
+	e.Title = title
+	e.ReleaseSummary = summary
+	e.ReleaseURL = url
+
+	return e
+}
+
+// WithDisplayPrecision sets the number of decimal places shown for the progress
+// of an install.
+func (e *UpdateEntity) WithDisplayPrecision(precision int) *UpdateEntity {
+	e.DisplayPrecision = precision
+
+	return e
+}
+
+func (e *UpdateEntity) WithDetails(options ...DetailsOption) *UpdateEntity {
+	e.EntityDetails = WithDetails(Update, options...)
+
+	return e
+}
+
+// WithState configures the state used to report the installed and latest
+// versions and the progress of any install.
+func (e *UpdateEntity) WithState(options ...StateOption) *UpdateEntity {
+	e.EntityState = WithStateOptions(options...)
+	e.StateTopic = generateTopic("state", e.EntityDetails)
+
+	return e
+}
+
+// WithCommand configures the command used to start an install.
+func (e *UpdateEntity) WithCommand(options ...CommandOption) *UpdateEntity {
+	e.EntityCommand = WithCommandOptions(options...)
+	e.CommandTopic = generateTopic("set", e.EntityDetails)
+
+	return e
+}
+
+// WithInstallHandler configures the command of the update entity to run the
+// given handler when an install is requested. The handler is run in its own
+// goroutine and can use the UpdateProgress it is passed to report the progress
+// of the install, which will be sent on the given channel. If the handler
+// returns an error, the install is reported as no longer in progress. Install
+// commands received while the handler is still running are logged and ignored.
+func (e *UpdateEntity) WithInstallHandler(msgCh chan *mqttapi.Msg, handler func(progress *UpdateProgress) error, options ...CommandOption) *UpdateEntity {
+	options = append(options, CommandCallback(func(p *paho.Publish) {
+		if string(p.Payload) != payloadOrDefault(e.PayloadInstall, updatePayloadInstall) {
+			slog.Warn("Could not decode command payload.",
+				slog.String("topic", p.Topic),
+				slog.Any("error", fmt.Errorf("%w: %s", ErrUnknownPayload, string(p.Payload))))
+
+			return
+		}
+
+		if !e.installing.CompareAndSwap(false, true) {
+			slog.Warn("Ignoring install command, install already in progress.",
+				slog.String("entity", e.Name))
+
+			return
+		}
+
+		progress := &UpdateProgress{entity: e, msgCh: msgCh}
+
+		go func() {
+			defer e.installing.Store(false)
+
+			if err := handler(progress); err != nil {
+				slog.Warn("Install failed.",
+					slog.String("entity", e.Name),
+					slog.Any("error", err))
+				progress.send(&UpdateState{InProgress: false})
+			}
+		}()
+	}))
+
+	return e.WithCommand(options...)
+}
+
+func (e *UpdateEntity) WithAttributes(options ...AttributeOption) *UpdateEntity {
+	e.EntityAttributes = WithAttributesOptions(options...)
+	e.AttributesTopic = generateTopic("attributes", e.EntityDetails)
+
+	return e
+}
+
+// WithAvailability configures the topics on which the availability of the
+// entity is reported.
+func (e *UpdateEntity) WithAvailability(options ...AvailabilityOption) *UpdateEntity {
+	e.EntityAvailability = WithAvailabilityOptions(options...)
+
+	return e
+}
+
+// MarshalUpdateState will generate an *mqtt.Msg for the given update state that
+// can be used to publish the state of the update entity.
+func (e *UpdateEntity) MarshalUpdateState(state *UpdateState) (*mqttapi.Msg, error) {
+	if e.EntityState == nil {
+		return nil, fmt.Errorf("could not marshal state: %w", ErrNoStateTopic)
+	}
+
+	if err := validateEntity(state); err != nil {
+		return nil, fmt.Errorf("state is invalid: %w", err)
+	}
+
+	payload, err := json.Marshal(state)
+	if err != nil {
+		return nil, fmt.Errorf("could not marshal state: %w", err)
+	}
+
+	return mqttapi.NewMsg(e.StateTopic, payload), nil
+}
+
+func (e *UpdateEntity) MarshalConfig() (*mqttapi.Msg, error) {
+	var (
+		cfg []byte
+		err error
+	)
+
+	if e.EntityCommand != nil && e.PayloadInstall == "" {
+		e.PayloadInstall = updatePayloadInstall
+	}
+
+	if err = validateEntity(e); err != nil {
+		return nil, fmt.Errorf("entity config is invalid: %w", err)
+	}
+
+	configTopic := discoveryTopic(e.EntityDetails)
+
+	if cfg, err = marshalConfig(e, e.EntityDetails); err != nil {
+		return nil, fmt.Errorf("marshal config: %w", err)
+	}
+
+	return mqttapi.NewMsg(configTopic, cfg), nil
+}
+
+func (e *UpdateEntity) validate() error {
+	return validateNoStateDeviceClass(e.EntityState, "WithUpdateType")
+}
+
+func NewUpdateEntity() *UpdateEntity {
+	return &UpdateEntity{}
+}
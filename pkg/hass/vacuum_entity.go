@@ -0,0 +1,281 @@
+// Copyright (c) 2024 Joshua Rich <joshua.rich@gmail.com>
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+//go:generate go run golang.org/x/tools/cmd/stringer -type=VacuumCommand,VacuumState,VacuumFeature -output vacuum_entity_generated.go -linecomment
+package hass
+
+import (
+	"encoding/json"
+	"fmt"
+
+	mqttapi "github.com/joshuar/go-hass-anything/v12/pkg/mqtt"
+)
+
+const (
+	VacuumCommandStart        VacuumCommand = iota // start
+	VacuumCommandPause                             // pause
+	VacuumCommandStop                              // stop
+	VacuumCommandReturnToBase                      // return_to_base
+	VacuumCommandLocate                            // locate
+	VacuumCommandCleanSpot                         // clean_spot
+)
+
+// VacuumCommand is a command sent to a vacuum by Home Assistant.
+type VacuumCommand int
+
+const (
+	VacuumStateDocked    VacuumState = iota // docked
+	VacuumStateCleaning                     // cleaning
+	VacuumStatePaused                       // paused
+	VacuumStateIdle                         // idle
+	VacuumStateReturning                    // returning
+	VacuumStateError                        // error
+)
+
+// VacuumState is one of the states a vacuum can be in.
+type VacuumState int
+
+// MarshalText ensures the vacuum state is marshaled as its name.
+func (i VacuumState) MarshalText() ([]byte, error) {
+	return []byte(i.String()), nil
+}
+
+const (
+	VacuumFeatureStart       VacuumFeature = iota // start
+	VacuumFeatureStop                             // stop
+	VacuumFeaturePause                            // pause
+	VacuumFeatureReturnHome                       // return_home
+	VacuumFeatureBattery                          // battery
+	VacuumFeatureStatus                           // status
+	VacuumFeatureLocate                           // locate
+	VacuumFeatureCleanSpot                        // clean_spot
+	VacuumFeatureFanSpeed                         // fan_speed
+	VacuumFeatureSendCommand                      // send_command
+)
+
+// VacuumFeature is a feature supported by a vacuum.
+type VacuumFeature int
+
+// vacuumTransitions are the valid transitions between vacuum states. Any state
+// can transition to the error state.
+var vacuumTransitions = map[VacuumState][]VacuumState{
+	VacuumStateDocked:    {VacuumStateCleaning, VacuumStateIdle, VacuumStateError},
+	VacuumStateCleaning:  {VacuumStatePaused, VacuumStateIdle, VacuumStateReturning, VacuumStateError},
+	VacuumStatePaused:    {VacuumStateCleaning, VacuumStateIdle, VacuumStateReturning, VacuumStateError},
+	VacuumStateIdle:      {VacuumStateCleaning, VacuumStateReturning, VacuumStateDocked, VacuumStateError},
+	VacuumStateReturning: {VacuumStateDocked, VacuumStateCleaning, VacuumStatePaused, VacuumStateIdle, VacuumStateError},
+	VacuumStateError:     {VacuumStateIdle, VacuumStateDocked, VacuumStateReturning},
+}
+
+// VacuumStatus represents the state of a vacuum, as published on its state
+// topic. Only the fields that are set are sent.
+type VacuumStatus struct {
+	// BatteryLevel is the battery level of the vacuum, as a percentage.
+	BatteryLevel *int `json:"battery_level,omitempty" validate:"omitempty,gte=0,lte=100"`
+	// FanSpeed is the current fan speed. It should be one of the fan speeds of
+	// the vacuum.
+	FanSpeed string `json:"fan_speed,omitempty"`
+	// State is the current state of the vacuum.
+	State VacuumState `json:"state"`
+}
+
+// VacuumEntity represents an entity that controls a robot vacuum. For more
+// details see https://www.home-assistant.io/integrations/vacuum.mqtt/
+//
+//nolint:lll
+type VacuumEntity struct {
+	*EntityDetails
+	*EntityAvailability
+	*EntityCommand
+	*EntityState
+	*EntityAttributes
+	EntityCommands      `json:"-"`
+	SetFanSpeedTopic    string   `json:"set_fan_speed_topic,omitempty"`
+	SendCommandTopic    string   `json:"send_command_topic,omitempty"`
+	PayloadStart        string   `json:"payload_start,omitempty"`
+	PayloadPause        string   `json:"payload_pause,omitempty"`
+	PayloadStop         string   `json:"payload_stop,omitempty"`
+	PayloadReturnToBase string   `json:"payload_return_to_base,omitempty"`
+	PayloadLocate       string   `json:"payload_locate,omitempty"`
+	PayloadCleanSpot    string   `json:"payload_clean_spot,omitempty"`
+	FanSpeedList        []string `json:"fan_speed_list,omitempty" validate:"omitempty,unique"`
+	SupportedFeatures   []string `json:"supported_features,omitempty" validate:"omitempty,unique"`
+}
+
+// WithFeatures sets the features supported by the vacuum.
+func (e *VacuumEntity) WithFeatures(features ...VacuumFeature) *VacuumEntity {
+	e.SupportedFeatures = make([]string, 0, len(features))
+
+	for _, feature := range features {
+		e.SupportedFeatures = append(e.SupportedFeatures, feature.String())
+	}
+
+	return e
+}
+
+// WithCommandPayload sets the payload sent to the command topic for the given
+// command. By default, the payload for each command is its name (i.e.,
+// "start", "return_to_base", etc.).
+func (e *VacuumEntity) WithCommandPayload(command VacuumCommand, payload string) *VacuumEntity {
+	switch command {
+	case VacuumCommandStart:
+		e.PayloadStart = payload
+	case VacuumCommandPause:
+		e.PayloadPause = payload
+	case VacuumCommandStop:
+		e.PayloadStop = payload
+	case VacuumCommandReturnToBase:
+		e.PayloadReturnToBase = payload
+	case VacuumCommandLocate:
+		e.PayloadLocate = payload
+	case VacuumCommandCleanSpot:
+		e.PayloadCleanSpot = payload
+	}
+
+	return e
+}
+
+func (e *VacuumEntity) WithDetails(options ...DetailsOption) *VacuumEntity {
+	e.EntityDetails = WithDetails(Vacuum, options...)
+
+	return e
+}
+
+// WithState configures the state used to report the state, battery level and
+// fan speed of the vacuum. See MarshalVacuumState and NewStateMachine.
+func (e *VacuumEntity) WithState(options ...StateOption) *VacuumEntity {
+	e.EntityState = WithStateOptions(options...)
+	e.StateTopic = generateTopic("state", e.EntityDetails)
+
+	return e
+}
+
+// WithCommand configures the command used to start, pause, stop, return,
+// locate and spot clean the vacuum.
+func (e *VacuumEntity) WithCommand(options ...CommandOption) *VacuumEntity {
+	e.EntityCommand = WithCommandOptions(options...)
+	e.CommandTopic = generateTopic("set", e.EntityDetails)
+
+	return e
+}
+
+// WithCommandHandler configures the command of the vacuum to pass each command
+// received, decoded as a VacuumCommand, to the given handler. Commands that do
+// not match any of the configured command payloads are logged and ignored.
+func (e *VacuumEntity) WithCommandHandler(handler func(cmd VacuumCommand), options ...CommandOption) *VacuumEntity {
+	options = append(options, CommandCallback(decodeCommand(e.decodeCommand, handler)))
+
+	return e.WithCommand(options...)
+}
+
+// WithFanSpeeds sets the fan speeds supported by the vacuum and configures the
+// command used to set the fan speed. The payload of the command is the
+// requested fan speed.
+func (e *VacuumEntity) WithFanSpeeds(speeds []string, options ...CommandOption) *VacuumEntity {
+	e.FanSpeedList = speeds
+	e.SetFanSpeedTopic = e.addCommand("fan_speed", e.EntityDetails, options...).CommandTopic
+
+	return e
+}
+
+// WithSendCommand configures the command used to send custom commands to the
+// vacuum. The payload of the command is the custom command.
+func (e *VacuumEntity) WithSendCommand(options ...CommandOption) *VacuumEntity {
+	e.SendCommandTopic = e.addCommand("send_command", e.EntityDetails, options...).CommandTopic
+
+	return e
+}
+
+func (e *VacuumEntity) WithAttributes(options ...AttributeOption) *VacuumEntity {
+	e.EntityAttributes = WithAttributesOptions(options...)
+	e.AttributesTopic = generateTopic("attributes", e.EntityDetails)
+
+	return e
+}
+
+// WithAvailability configures the topics on which the availability of the
+// entity is reported.
+func (e *VacuumEntity) WithAvailability(options ...AvailabilityOption) *VacuumEntity {
+	e.EntityAvailability = WithAvailabilityOptions(options...)
+
+	return e
+}
+
+// NewStateMachine returns a StateMachine that tracks the state of the vacuum,
+// starting in the initial state. Each valid transition generates a message
+// that can be used to publish the new state.
+func (e *VacuumEntity) NewStateMachine(initial VacuumState) *StateMachine[VacuumState] {
+	return newStateMachine(initial, vacuumTransitions, func(state VacuumState) (*mqttapi.Msg, error) {
+		return e.MarshalVacuumState(&VacuumStatus{State: state})
+	})
+}
+
+// MarshalVacuumState will generate an *mqtt.Msg for the given vacuum status,
+// that can be used to publish the state of the vacuum.
+func (e *VacuumEntity) MarshalVacuumState(status *VacuumStatus) (*mqttapi.Msg, error) {
+	if e.EntityState == nil {
+		return nil, fmt.Errorf("could not marshal state: %w", ErrNoStateTopic)
+	}
+
+	if err := validateEntity(status); err != nil {
+		return nil, fmt.Errorf("state is invalid: %w", err)
+	}
+
+	payload, err := json.Marshal(status)
+	if err != nil {
+		return nil, fmt.Errorf("could not marshal state: %w", err)
+	}
+
+	return mqttapi.NewMsg(e.StateTopic, payload), nil
+}
+
+// MarshalSubscriptions will generate an *mqtt.Subscription for the command
+// topic of the vacuum and each of its fan speed and send command topics.
+func (e *VacuumEntity) MarshalSubscriptions() ([]*mqttapi.Subscription, error) {
+	return marshalSubscriptions(e.EntityCommand, &e.EntityCommands)
+}
+
+func (e *VacuumEntity) MarshalConfig() (*mqttapi.Msg, error) {
+	var (
+		cfg []byte
+		err error
+	)
+
+	if err = validateEntity(e); err != nil {
+		return nil, fmt.Errorf("entity config is invalid: %w", err)
+	}
+
+	configTopic := discoveryTopic(e.EntityDetails)
+
+	if cfg, err = marshalConfig(e, e.EntityDetails); err != nil {
+		return nil, fmt.Errorf("marshal config: %w", err)
+	}
+
+	return mqttapi.NewMsg(configTopic, cfg), nil
+}
+
+// decodeCommand converts a command payload into a VacuumCommand.
+func (e *VacuumEntity) decodeCommand(payload []byte) (VacuumCommand, error) {
+	switch string(payload) {
+	case payloadOrDefault(e.PayloadStart, VacuumCommandStart.String()):
+		return VacuumCommandStart, nil
+	case payloadOrDefault(e.PayloadPause, VacuumCommandPause.String()):
+		return VacuumCommandPause, nil
+	case payloadOrDefault(e.PayloadStop, VacuumCommandStop.String()):
+		return VacuumCommandStop, nil
+	case payloadOrDefault(e.PayloadReturnToBase, VacuumCommandReturnToBase.String()):
+		return VacuumCommandReturnToBase, nil
+	case payloadOrDefault(e.PayloadLocate, VacuumCommandLocate.String()):
+		return VacuumCommandLocate, nil
+	case payloadOrDefault(e.PayloadCleanSpot, VacuumCommandCleanSpot.String()):
+		return VacuumCommandCleanSpot, nil
+	default:
+		return 0, fmt.Errorf("%w: %s", ErrUnknownPayload, string(payload))
+	}
+}
+
+func NewVacuumEntity() *VacuumEntity {
+	return &VacuumEntity{}
+}
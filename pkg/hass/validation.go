@@ -42,13 +42,22 @@ func validateEntity[T any](object T) error {
 				errs = errors.Join(errs, fmt.Errorf("%s is required", err.Field()))
 			case err.Tag() == "required_without":
 				errs = errors.Join(errs, fmt.Errorf("%s cannot be set when %s is set", err.Field(), err.Param()))
+			case err.Tag() == "excluded_with":
+				errs = errors.Join(errs, fmt.Errorf("%s cannot be set when %s is set", err.Field(), err.Param()))
 			case err.StructField() == "Icon":
 				errs = errors.Join(errs, errors.New("icon should be of the form 'mdi:someicon'"))
+			case err.StructField() == "ObjectID":
+				errs = errors.Join(errs, errors.New("object id should not contain a '.'"))
 			default:
 				errs = errors.Join(errs, fmt.Errorf("%s failed to validate on %s tag", err.Field(), err.Tag()))
 			}
 		}
 	}
 
+	// Check any rules that span multiple fields of the entity.
+	if object, ok := any(object).(interface{ validate() error }); ok {
+		errs = errors.Join(errs, object.validate())
+	}
+
 	return errs
 }
@@ -0,0 +1,271 @@
+// Copyright (c) 2024 Joshua Rich <joshua.rich@gmail.com>
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+//go:generate go run golang.org/x/tools/cmd/stringer -type=ValveAction,ValveState,ValveType -output valve_entity_generated.go -linecomment
+package hass
+
+import (
+	"fmt"
+	"strconv"
+
+	mqttapi "github.com/joshuar/go-hass-anything/v12/pkg/mqtt"
+)
+
+const (
+	ValveActionOpen     ValveAction = iota // OPEN
+	ValveActionClose                       // CLOSE
+	ValveActionStop                        // STOP
+	ValveActionPosition                    // POSITION
+)
+
+// ValveAction is an action requested of a valve by Home Assistant.
+type ValveAction int
+
+const (
+	ValveStateOpen    ValveState = iota // open
+	ValveStateOpening                   // opening
+	ValveStateClosed                    // closed
+	ValveStateClosing                   // closing
+)
+
+// ValveState is one of the states a valve can be in.
+type ValveState int
+
+const (
+	ValveTypeNone  ValveType = iota //
+	ValveTypeWater                  // water
+	ValveTypeGas                    // gas
+)
+
+// ValveType is the type of valve, which defines how it is displayed in Home
+// Assistant.
+type ValveType int
+
+// ValveCommand represents a command received for a valve entity.
+type ValveCommand struct {
+	// Position is the requested position of the valve. It is only set when the
+	// Action is ValveActionPosition.
+	Position *int
+	// Action is the requested action.
+	Action ValveAction
+}
+
+// ValveEntity represents an entity that controls a valve. A valve can either be
+// opened and closed, or, if it reports its position, set to a specific
+// position. For more details see
+// https://www.home-assistant.io/integrations/valve.mqtt/
+type ValveEntity struct {
+	*EntityDetails
+	*EntityAvailability
+	*EntityCommand
+	*EntityState
+	*EntityAttributes
+	ValveType       string `json:"device_class,omitempty"`
+	PayloadOpen     string `json:"payload_open,omitempty"`
+	PayloadClose    string `json:"payload_close,omitempty"`
+	PayloadStop     string `json:"payload_stop,omitempty"`
+	StateOpen       string `json:"state_open,omitempty"`
+	StateOpening    string `json:"state_opening,omitempty"`
+	StateClosed     string `json:"state_closed,omitempty"`
+	StateClosing    string `json:"state_closing,omitempty"`
+	PositionOpen    *int   `json:"position_open,omitempty"`
+	PositionClosed  *int   `json:"position_closed,omitempty"`
+	ReportsPosition bool   `json:"reports_position,omitempty"`
+	Optimistic      bool   `json:"optimistic,omitempty"`
+}
+
+// OptimisticMode ensures the valve works in optimistic mode.
+func (e *ValveEntity) OptimisticMode() *ValveEntity {
+	e.Optimistic = true
+
+	return e
+}
+
+// WithValveType sets the type of valve, defining how it gets displayed in Home
+// Assistant. The DeviceClass state option cannot be used for valves.
+func (e *ValveEntity) WithValveType(valveType ValveType) *ValveEntity {
+	e.ValveType = valveType.String()
+
+	return e
+}
+
+// WithActionPayload sets the payload sent to the command topic for the given
+// action. By default, the payload for each action is OPEN, CLOSE and STOP
+// respectively. Setting a payload for ValveActionPosition has no effect, the
+// position is always sent as a number.
+func (e *ValveEntity) WithActionPayload(action ValveAction, payload string) *ValveEntity {
+	switch action {
+	case ValveActionOpen:
+		e.PayloadOpen = payload
+	case ValveActionClose:
+		e.PayloadClose = payload
+	case ValveActionStop:
+		e.PayloadStop = payload
+	case ValveActionPosition:
+	}
+
+	return e
+}
+
+// WithStatePayload sets the payload that represents the given state on the
+// state topic. By default, the payload for each state is its name (i.e.,
+// "open", "closing", etc.).
+func (e *ValveEntity) WithStatePayload(state ValveState, payload string) *ValveEntity {
+	switch state {
+	case ValveStateOpen:
+		e.StateOpen = payload
+	case ValveStateOpening:
+		e.StateOpening = payload
+	case ValveStateClosed:
+		e.StateClosed = payload
+	case ValveStateClosing:
+		e.StateClosing = payload
+	}
+
+	return e
+}
+
+// WithPosition indicates the valve reports and can be set to a position, and
+// sets the values that represent the fully closed and fully open positions.
+// Home Assistant will then send the requested position instead of the open and
+// close payloads.
+func (e *ValveEntity) WithPosition(closed, open int) *ValveEntity {
+	e.ReportsPosition = true
+	e.PositionClosed = &closed
+	e.PositionOpen = &open
+
+	return e
+}
+
+func (e *ValveEntity) WithDetails(options ...DetailsOption) *ValveEntity {
+	e.EntityDetails = WithDetails(Valve, options...)
+
+	return e
+}
+
+// WithState configures the state used to report the state or position of the
+// valve.
+func (e *ValveEntity) WithState(options ...StateOption) *ValveEntity {
+	e.EntityState = WithStateOptions(options...)
+	e.StateTopic = generateTopic("state", e.EntityDetails)
+
+	return e
+}
+
+// WithCommand configures the command used to open, close, stop or set the
+// position of the valve.
+func (e *ValveEntity) WithCommand(options ...CommandOption) *ValveEntity {
+	e.EntityCommand = WithCommandOptions(options...)
+	e.CommandTopic = generateTopic("set", e.EntityDetails)
+
+	return e
+}
+
+// WithCommandHandler configures the command of the valve to pass each command
+// received, decoded as a ValveCommand, to the given handler. Commands that do
+// not match any of the configured action payloads or a valid position are
+// logged and ignored.
+func (e *ValveEntity) WithCommandHandler(handler func(cmd *ValveCommand), options ...CommandOption) *ValveEntity {
+	options = append(options, CommandCallback(decodeCommand(e.decodeCommand, handler)))
+
+	return e.WithCommand(options...)
+}
+
+func (e *ValveEntity) WithAttributes(options ...AttributeOption) *ValveEntity {
+	e.EntityAttributes = WithAttributesOptions(options...)
+	e.AttributesTopic = generateTopic("attributes", e.EntityDetails)
+
+	return e
+}
+
+// WithAvailability configures the topics on which the availability of the
+// entity is reported.
+func (e *ValveEntity) WithAvailability(options ...AvailabilityOption) *ValveEntity {
+	e.EntityAvailability = WithAvailabilityOptions(options...)
+
+	return e
+}
+
+// MarshalValveState will generate an *mqtt.Msg for the given valve state,
+// using the payload configured for that state, that can be used to publish the
+// state of the valve.
+func (e *ValveEntity) MarshalValveState(state ValveState) (*mqttapi.Msg, error) {
+	if e.EntityState == nil {
+		return nil, fmt.Errorf("could not marshal state: %w", ErrNoStateTopic)
+	}
+
+	var payload string
+
+	switch state {
+	case ValveStateOpen:
+		payload = e.StateOpen
+	case ValveStateOpening:
+		payload = e.StateOpening
+	case ValveStateClosed:
+		payload = e.StateClosed
+	case ValveStateClosing:
+		payload = e.StateClosing
+	}
+
+	return mqttapi.NewMsg(e.StateTopic, []byte(payloadOrDefault(payload, state.String()))), nil
+}
+
+// MarshalValvePosition will generate an *mqtt.Msg for the given valve
+// position, that can be used to publish the position of the valve.
+func (e *ValveEntity) MarshalValvePosition(position int) (*mqttapi.Msg, error) {
+	if e.EntityState == nil {
+		return nil, fmt.Errorf("could not marshal state: %w", ErrNoStateTopic)
+	}
+
+	return mqttapi.NewMsg(e.StateTopic, []byte(strconv.Itoa(position))), nil
+}
+
+func (e *ValveEntity) MarshalConfig() (*mqttapi.Msg, error) {
+	var (
+		cfg []byte
+		err error
+	)
+
+	if err = validateEntity(e); err != nil {
+		return nil, fmt.Errorf("entity config is invalid: %w", err)
+	}
+
+	configTopic := discoveryTopic(e.EntityDetails)
+
+	if cfg, err = marshalConfig(e, e.EntityDetails); err != nil {
+		return nil, fmt.Errorf("marshal config: %w", err)
+	}
+
+	return mqttapi.NewMsg(configTopic, cfg), nil
+}
+
+// decodeCommand converts a command payload into a ValveCommand. If the valve
+// reports its position, a numeric payload is treated as a requested position.
+func (e *ValveEntity) decodeCommand(payload []byte) (*ValveCommand, error) {
+	if e.ReportsPosition {
+		if position, err := strconv.Atoi(string(payload)); err == nil {
+			return &ValveCommand{Action: ValveActionPosition, Position: &position}, nil
+		}
+	}
+
+	switch string(payload) {
+	case payloadOrDefault(e.PayloadOpen, ValveActionOpen.String()):
+		return &ValveCommand{Action: ValveActionOpen}, nil
+	case payloadOrDefault(e.PayloadClose, ValveActionClose.String()):
+		return &ValveCommand{Action: ValveActionClose}, nil
+	case payloadOrDefault(e.PayloadStop, ValveActionStop.String()):
+		return &ValveCommand{Action: ValveActionStop}, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownPayload, string(payload))
+	}
+}
+
+func (e *ValveEntity) validate() error {
+	return validateNoStateDeviceClass(e.EntityState, "WithValveType")
+}
+
+func NewValveEntity() *ValveEntity {
+	return &ValveEntity{}
+}
@@ -0,0 +1,216 @@
+// Copyright (c) 2024 Joshua Rich <joshua.rich@gmail.com>
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+//go:generate go run golang.org/x/tools/cmd/stringer -type=WaterHeaterMode -output water_heater_entity_generated.go -linecomment
+package hass
+
+import (
+	"fmt"
+
+	mqttapi "github.com/joshuar/go-hass-anything/v12/pkg/mqtt"
+)
+
+const (
+	WaterHeaterModeOff         WaterHeaterMode = iota // off
+	WaterHeaterModeEco                                // eco
+	WaterHeaterModeElectric                           // electric
+	WaterHeaterModeGas                                // gas
+	WaterHeaterModeHeatPump                           // heat_pump
+	WaterHeaterModeHighDemand                         // high_demand
+	WaterHeaterModePerformance                        // performance
+)
+
+// WaterHeaterMode is an operating mode of a water heater entity.
+type WaterHeaterMode int
+
+// WaterHeaterModes sets the list of modes supported by a water heater entity.
+// It can be used in place of ModeList when configuring the modes of a water
+// heater entity.
+func WaterHeaterModes(modes ...WaterHeaterMode) ModeOption {
+	list := make([]string, 0, len(modes))
+
+	for _, mode := range modes {
+		list = append(list, mode.String())
+	}
+
+	return ModeList(list...)
+}
+
+// WaterHeaterEntity represents an entity that controls a water heater. Each of
+// the properties that can be controlled or reported has its own command and
+// state topic. For more details see
+// https://www.home-assistant.io/integrations/water_heater.mqtt/
+//
+//nolint:lll
+type WaterHeaterEntity struct {
+	*EntityDetails
+	*EntityAvailability
+	*EntityAttributes
+	*EntityModes
+	EntityCommands             `json:"-"`
+	EntityStates               `json:"-"`
+	TemperatureCommandTopic    string  `json:"temperature_command_topic,omitempty"`
+	TemperatureCommandTemplate string  `json:"temperature_command_template,omitempty"`
+	TemperatureStateTopic      string  `json:"temperature_state_topic,omitempty"`
+	TemperatureStateTemplate   string  `json:"temperature_state_template,omitempty"`
+	CurrentTemperatureTopic    string  `json:"current_temperature_topic,omitempty"`
+	CurrentTemperatureTemplate string  `json:"current_temperature_template,omitempty"`
+	PowerCommandTopic          string  `json:"power_command_topic,omitempty"`
+	PowerCommandTemplate       string  `json:"power_command_template,omitempty"`
+	PayloadOn                  string  `json:"payload_on,omitempty"`
+	PayloadOff                 string  `json:"payload_off,omitempty"`
+	TemperatureUnit            string  `json:"temperature_unit,omitempty" validate:"omitempty,oneof=C F"`
+	MinTemp                    float64 `json:"min_temp,omitempty"`
+	MaxTemp                    float64 `json:"max_temp,omitempty" validate:"omitempty,gtfield=MinTemp"`
+	Precision                  float64 `json:"precision,omitempty" validate:"omitempty,gt=0"`
+	Initial                    float64 `json:"initial,omitempty"`
+	Optimistic                 bool    `json:"optimistic,omitempty"`
+	Retain                     bool    `json:"retain,omitempty"`
+}
+
+// OptimisticMode ensures the water heater works in optimistic mode.
+func (e *WaterHeaterEntity) OptimisticMode() *WaterHeaterEntity {
+	e.Optimistic = true
+
+	return e
+}
+
+// RetainCommands ensures that Home Assistant publishes the commands of the
+// water heater as retained messages, so that the last command is received on
+// (re)subscribing.
+func (e *WaterHeaterEntity) RetainCommands() *WaterHeaterEntity {
+	e.Retain = true
+
+	return e
+}
+
+// WithPowerPayloads sets the payloads sent to the power command topic to turn
+// the water heater on and off. Defaults to ON and OFF respectively.
+func (e *WaterHeaterEntity) WithPowerPayloads(on, off string) *WaterHeaterEntity {
+	e.PayloadOn = on
+	e.PayloadOff = off
+
+	return e
+}
+
+// WithTemperatureRange sets the minimum and maximum target temperature, as well
+// as the initial target temperature.
+//
+//nolint:predeclared
+func (e *WaterHeaterEntity) WithTemperatureRange(min, max, initial float64) *WaterHeaterEntity {
+	e.MinTemp = min
+	e.MaxTemp = max
+	e.Initial = initial
+
+	return e
+}
+
+// WithTemperatureUnit sets the unit of the temperatures used by the entity.
+// Must be either "C" or "F". Defaults to the unit system of Home Assistant.
+func (e *WaterHeaterEntity) WithTemperatureUnit(unit string) *WaterHeaterEntity {
+	e.TemperatureUnit = unit
+
+	return e
+}
+
+// WithPrecision sets the precision of the temperatures reported by the entity.
+// Must be one of 0.1, 0.5 or 1.
+func (e *WaterHeaterEntity) WithPrecision(precision float64) *WaterHeaterEntity {
+	e.Precision = precision
+
+	return e
+}
+
+func (e *WaterHeaterEntity) WithDetails(options ...DetailsOption) *WaterHeaterEntity {
+	e.EntityDetails = WithDetails(WaterHeater, options...)
+
+	return e
+}
+
+// WithModes configures the modes supported by the water heater, and the
+// command and state used to set and report the current mode.
+func (e *WaterHeaterEntity) WithModes(options ...ModeOption) *WaterHeaterEntity {
+	e.EntityModes = newEntityModes(e.EntityDetails, &e.EntityCommands, &e.EntityStates, options...)
+
+	return e
+}
+
+// WithTemperatureCommand configures the command used to set the target
+// temperature.
+func (e *WaterHeaterEntity) WithTemperatureCommand(options ...CommandOption) *WaterHeaterEntity {
+	command := e.addCommand("temperature", e.EntityDetails, options...)
+	e.TemperatureCommandTopic = command.CommandTopic
+	e.TemperatureCommandTemplate = command.CommandTemplate
+
+	return e
+}
+
+// WithTemperatureState configures the state used to report the target
+// temperature.
+func (e *WaterHeaterEntity) WithTemperatureState(options ...StateOption) *WaterHeaterEntity {
+	state := e.addState("temperature", e.EntityDetails, options...)
+	e.TemperatureStateTopic = state.StateTopic
+	e.TemperatureStateTemplate = state.ValueTemplate
+
+	return e
+}
+
+// WithCurrentTemperatureState configures the state used to report the current
+// (measured) temperature.
+func (e *WaterHeaterEntity) WithCurrentTemperatureState(options ...StateOption) *WaterHeaterEntity {
+	state := e.addState("current_temperature", e.EntityDetails, options...)
+	e.CurrentTemperatureTopic = state.StateTopic
+	e.CurrentTemperatureTemplate = state.ValueTemplate
+
+	return e
+}
+
+// WithPowerCommand configures the command used to turn the water heater on or
+// off.
+func (e *WaterHeaterEntity) WithPowerCommand(options ...CommandOption) *WaterHeaterEntity {
+	command := e.addCommand("power", e.EntityDetails, options...)
+	e.PowerCommandTopic = command.CommandTopic
+	e.PowerCommandTemplate = command.CommandTemplate
+
+	return e
+}
+
+func (e *WaterHeaterEntity) WithAttributes(options ...AttributeOption) *WaterHeaterEntity {
+	e.EntityAttributes = WithAttributesOptions(options...)
+	e.AttributesTopic = generateTopic("attributes", e.EntityDetails)
+
+	return e
+}
+
+// WithAvailability configures the topics on which the availability of the
+// entity is reported.
+func (e *WaterHeaterEntity) WithAvailability(options ...AvailabilityOption) *WaterHeaterEntity {
+	e.EntityAvailability = WithAvailabilityOptions(options...)
+
+	return e
+}
+
+func (e *WaterHeaterEntity) MarshalConfig() (*mqttapi.Msg, error) {
+	var (
+		cfg []byte
+		err error
+	)
+
+	if err = validateEntity(e); err != nil {
+		return nil, fmt.Errorf("entity config is invalid: %w", err)
+	}
+
+	configTopic := discoveryTopic(e.EntityDetails)
+
+	if cfg, err = marshalConfig(e, e.EntityDetails); err != nil {
+		return nil, fmt.Errorf("marshal config: %w", err)
+	}
+
+	return mqttapi.NewMsg(configTopic, cfg), nil
+}
+
+func NewWaterHeaterEntity() *WaterHeaterEntity {
+	return &WaterHeaterEntity{}
+}
@@ -12,6 +12,7 @@ import (
 	"log/slog"
 	"net/url"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/eclipse/paho.golang/autopaho"
@@ -53,8 +54,19 @@ type Subscription struct {
 
 // Client is the connection to the MQTT broker.
 type Client struct {
-	conn     *autopaho.ConnectionManager
-	haStatus chan string
+	conn           *autopaho.ConnectionManager
+	haStatus       chan string
+	onlineHandlers []func()
+	mu             sync.Mutex
+}
+
+// OnHomeAssistantOnline registers a function to be called whenever Home
+// Assistant comes online, such as after it has been restarted.
+func (c *Client) OnHomeAssistantOnline(handler func()) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.onlineHandlers = append(c.onlineHandlers, handler)
 }
 
 // Publish will send the list of messages it is passed to the broker that the
@@ -76,8 +88,10 @@ func (c *Client) Unpublish(ctx context.Context, msgs ...*Msg) error {
 
 	newMsgs := make([]*Msg, 0, len(msgs))
 
+	// Publish an empty retained message to each topic, which also clears any
+	// message retained on the topic.
 	for _, msg := range msgs {
-		newMsgs = append(newMsgs, NewMsg(msg.Topic, []byte(``)))
+		newMsgs = append(newMsgs, NewMsg(msg.Topic, []byte(``)).Retain())
 	}
 
 	err := publish(ctx, c.conn, newMsgs...)
@@ -85,12 +99,44 @@ func (c *Client) Unpublish(ctx context.Context, msgs ...*Msg) error {
 	return err
 }
 
+// ClientOption is used to configure optional behaviour of the client.
+type ClientOption func(*clientOptions) *clientOptions
+
+type clientOptions struct {
+	availability *availability
+}
+
+// availability is a topic on which the client reports whether it is
+// connected.
+type availability struct {
+	topic   string
+	online  []byte
+	offline []byte
+}
+
+// WithAvailability reports whether the client is connected on the given topic.
+// The online payload is published each time the client connects. The offline
+// payload is registered as the Last Will of the client, which the broker will
+// publish if the client disconnects unexpectedly. Both are retained.
+func WithAvailability(topic string, online, offline []byte) ClientOption {
+	return func(o *clientOptions) *clientOptions {
+		o.availability = &availability{topic: topic, online: online, offline: offline}
+
+		return o
+	}
+}
+
 //nolint:exhaustruct
-func NewClient(ctx context.Context, prefs Preferences, subscriptions []*Subscription, configs []*Msg) (*Client, error) {
+func NewClient(ctx context.Context, prefs Preferences, subscriptions []*Subscription, configs []*Msg, options ...ClientOption) (*Client, error) {
 	if prefs == nil {
 		return nil, ErrNoPrefs
 	}
 
+	opts := &clientOptions{}
+	for _, option := range options {
+		opts = option(opts)
+	}
+
 	subOpts := make([]paho.SubscribeOptions, 0, len(subscriptions))
 
 	client := &Client{
@@ -116,7 +162,7 @@ func NewClient(ctx context.Context, prefs Preferences, subscriptions []*Subscrip
 		client.haStatus <- string(p.Payload)
 	})
 
-	connOpts := genConnOpts(ctx, prefs, subOpts, router)
+	connOpts := genConnOpts(ctx, prefs, subOpts, router, opts.availability)
 
 	conn, err := autopaho.NewConnection(ctx, connOpts) // starts process; will reconnect until context canceled.
 	if err != nil {
@@ -140,7 +186,7 @@ func NewClient(ctx context.Context, prefs Preferences, subscriptions []*Subscrip
 }
 
 //nolint:exhaustruct
-func genConnOpts(ctx context.Context, prefs Preferences, subOpts []paho.SubscribeOptions, router *paho.StandardRouter) autopaho.ClientConfig { //nolint:lll
+func genConnOpts(ctx context.Context, prefs Preferences, subOpts []paho.SubscribeOptions, router *paho.StandardRouter, avail *availability) autopaho.ClientConfig { //nolint:lll
 	// Set a client ID for this connection.
 	clientID := "go_hass_anything_" + strconv.Itoa(time.Now().Second())
 
@@ -169,6 +215,14 @@ func genConnOpts(ctx context.Context, prefs Preferences, subOpts []paho.Subscrib
 					slog.Any("error", err))
 			}
 			slog.Debug("Subscriptions added to MQTT.")
+			// Mark the client as available, replacing any Last Will published
+			// by the broker while the client was disconnected.
+			if avail != nil {
+				if err := publish(ctx, cm, NewMsg(avail.topic, avail.online).Retain()); err != nil {
+					slog.Warn("Failed to publish availability to MQTT.",
+						slog.Any("error", err))
+				}
+			}
 		},
 		OnConnectError: func(err error) {
 			slog.Error("Error establishing MQTT connection.",
@@ -205,6 +259,17 @@ func genConnOpts(ctx context.Context, prefs Preferences, subOpts []paho.Subscrib
 		},
 	}
 
+	// If an availability topic is set, have the broker mark the client
+	// unavailable if it disconnects unexpectedly.
+	if avail != nil {
+		connOpts.WillMessage = &paho.WillMessage{
+			Retain:  true,
+			QoS:     1,
+			Topic:   avail.topic,
+			Payload: avail.offline,
+		}
+	}
+
 	// If a username/password is set, add those to the connection options.
 	if prefs.User() != "" && prefs.Password() != "" {
 		connOpts.ConnectUsername = prefs.User()
@@ -228,6 +293,7 @@ func publish(ctx context.Context, conn *autopaho.ConnectionManager, msgs ...*Msg
 			QoS:     1,
 			Topic:   msg.Topic,
 			Payload: msg.Message,
+			Retain:  msg.Retained,
 		}); err != nil {
 			slog.Error("Error publishing message.",
 				slog.String("topic", msg.Topic),
@@ -247,6 +313,12 @@ func (c *Client) monitorHAStatus(ctx context.Context, configs ...*Msg) {
 				case "online":
 					slog.Debug("Home Assistant detected online.")
 
+					c.mu.Lock()
+					for _, handler := range c.onlineHandlers {
+						handler()
+					}
+					c.mu.Unlock()
+
 					if err := c.Publish(ctx, configs...); err != nil {
 						slog.Warn("Could not publish configs to MQTT",
 							slog.Any("error", err))